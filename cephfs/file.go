@@ -12,8 +12,10 @@ import "C"
 
 import (
 	"io"
+	"time"
 	"unsafe"
 
+	"github.com/ceph/go-ceph/common/observer"
 	"github.com/ceph/go-ceph/internal/cutil"
 )
 
@@ -42,6 +44,11 @@ const (
 type File struct {
 	mount *MountInfo
 	fd    C.int
+	path  string
+
+	// observer, if set, is informed about the I/O operations performed on
+	// the file.
+	observer observer.Observer
 }
 
 // Open a file at the given path. The flags are the same os flags as
@@ -60,7 +67,7 @@ func (mount *MountInfo) Open(path string, flags int, mode uint32) (*File, error)
 	if ret < 0 {
 		return nil, getError(ret)
 	}
-	return &File{mount: mount, fd: ret}, nil
+	return &File{mount: mount, fd: ret, path: path}, nil
 }
 
 func (f *File) validate() error {
@@ -116,7 +123,13 @@ func (f *File) read(buf []byte, offset int64) (int, error) {
 // Read data from file. Up to len(buf) bytes will be read from the file.
 // The number of bytes read will be returned.
 // When nothing is left to read from the file, Read returns, 0, io.EOF.
-func (f *File) Read(buf []byte) (int, error) {
+func (f *File) Read(buf []byte) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Read", n, start, err)
+		}(time.Now())
+	}
+
 	// to-consider: should we mimic Go's behavior of returning an
 	// io.ErrShortWrite error if write length < buf size?
 	return f.read(buf, -1)
@@ -126,7 +139,13 @@ func (f *File) Read(buf []byte) (int, error) {
 // Up to len(buf) bytes will be read from the file.
 // The number of bytes read will be returned.
 // When nothing is left to read from the file, ReadAt returns, 0, io.EOF.
-func (f *File) ReadAt(buf []byte, offset int64) (int, error) {
+func (f *File) ReadAt(buf []byte, offset int64) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.ReadAt", n, start, err)
+		}(time.Now())
+	}
+
 	if offset < 0 {
 		return 0, errInvalid
 	}
@@ -143,7 +162,13 @@ func (f *File) ReadAt(buf []byte, offset int64) (int, error) {
 //
 //	int ceph_preadv(struct ceph_mount_info *cmount, int fd, const struct iovec *iov, int iovcnt,
 //	                int64_t offset);
-func (f *File) Preadv(data [][]byte, offset int64) (int, error) {
+func (f *File) Preadv(data [][]byte, offset int64) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Preadv", n, start, err)
+		}(time.Now())
+	}
+
 	if err := f.validate(); err != nil {
 		return 0, err
 	}
@@ -189,13 +214,25 @@ func (f *File) write(buf []byte, offset int64) (int, error) {
 
 // Write data from buf to the file.
 // The number of bytes written is returned.
-func (f *File) Write(buf []byte) (int, error) {
+func (f *File) Write(buf []byte) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Write", n, start, err)
+		}(time.Now())
+	}
+
 	return f.write(buf, -1)
 }
 
 // WriteAt writes data from buf to the file at the specified offset.
 // The number of bytes written is returned.
-func (f *File) WriteAt(buf []byte, offset int64) (int, error) {
+func (f *File) WriteAt(buf []byte, offset int64) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.WriteAt", n, start, err)
+		}(time.Now())
+	}
+
 	if offset < 0 {
 		return 0, errInvalid
 	}
@@ -210,7 +247,13 @@ func (f *File) WriteAt(buf []byte, offset int64) (int, error) {
 //
 //	int ceph_pwritev(struct ceph_mount_info *cmount, int fd, const struct iovec *iov, int iovcnt,
 //	                 int64_t offset);
-func (f *File) Pwritev(data [][]byte, offset int64) (int, error) {
+func (f *File) Pwritev(data [][]byte, offset int64) (n int, err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Pwritev", n, start, err)
+		}(time.Now())
+	}
+
 	if err := f.validate(); err != nil {
 		return 0, err
 	}
@@ -386,7 +429,13 @@ func (f *File) Flock(operation LockOp, owner uint64) error {
 // Implements:
 //
 //	int ceph_fsync(struct ceph_mount_info *cmount, int fd, int syncdataonly);
-func (f *File) Fsync(sync SyncChoice) error {
+func (f *File) Fsync(sync SyncChoice) (err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Fsync", 0, start, err)
+		}(time.Now())
+	}
+
 	if err := f.validate(); err != nil {
 		return err
 	}
@@ -416,7 +465,13 @@ func (f *File) Sync() error {
 // Implements:
 //
 //	int ceph_ftruncate(struct ceph_mount_info *cmount, int fd, int64_t size);
-func (f *File) Truncate(size int64) error {
+func (f *File) Truncate(size int64) (err error) {
+	if f.observer != nil {
+		defer func(start time.Time) {
+			f.observe("cephfs.File.Truncate", 0, start, err)
+		}(time.Now())
+	}
+
 	if err := f.validate(); err != nil {
 		return err
 	}
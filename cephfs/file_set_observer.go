@@ -0,0 +1,21 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cephfs
+
+import (
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// SetObserver sets the observer that is informed about the I/O operations
+// performed on the file, which are the Read, ReadAt, Preadv, Write, WriteAt,
+// Pwritev, Fsync and Truncate functions of the File. Passing nil removes the
+// observer. The observer must not be changed while the file is in use by
+// other goroutines.
+func (f *File) SetObserver(o observer.Observer) error {
+	if err := f.validate(); err != nil {
+		return err
+	}
+	f.observer = o
+	return nil
+}
@@ -0,0 +1,58 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cephfs
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/observertest"
+)
+
+func TestFileSetObserver(t *testing.T) {
+	mount := fsConnect(t)
+	defer fsDisconnect(t, mount)
+	fname := "TestFileSetObserver.txt"
+
+	f, err := mount.Open(fname, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, mount.Unlink(fname)) }()
+	defer func() { assert.NoError(t, f.Close()) }()
+
+	assert.Equal(t, ErrNotConnected, (&File{}).SetObserver(nil))
+
+	rec := &observertest.Recorder{}
+	require.NoError(t, f.SetObserver(rec))
+	data := []byte("observed data")
+	n, err := f.WriteAt(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	buf := make([]byte, 64)
+	n, err = f.ReadAt(buf, 0)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	_, err = f.ReadAt(buf, -1)
+	assert.Error(t, err)
+	require.NoError(t, f.Fsync(SyncAll))
+
+	ops := rec.Operations()
+	require.Len(t, ops, 4)
+	for _, op := range ops {
+		assert.Equal(t, fname, op.Path)
+	}
+	assert.Equal(t, "cephfs.File.WriteAt", ops[0].Name)
+	assert.EqualValues(t, len(data), ops[0].Bytes)
+	assert.Equal(t, "cephfs.File.ReadAt", ops[1].Name)
+	assert.EqualValues(t, len(data), ops[1].Bytes)
+	assert.Equal(t, errInvalid, ops[2].Err)
+	assert.Equal(t, "cephfs.File.Fsync", ops[3].Name)
+
+	require.NoError(t, f.SetObserver(nil))
+	_, err = f.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Len(t, rec.Operations(), 4)
+}
@@ -0,0 +1,19 @@
+package cephfs
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// observe informs the observer of the file about a completed operation. It
+// must only be called if the file has an observer.
+func (f *File) observe(name string, bytes int, start time.Time, err error) {
+	f.observer.ObserveOperation(observer.Operation{
+		Name:     name,
+		Path:     f.path,
+		Bytes:    int64(bytes),
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
@@ -0,0 +1,146 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"errors"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const exportedKeyringPrefix = "exported keyring for"
+
+var errNoEntity = errors.New("no entity found in response")
+
+// Entity is a cephx user, like "client.admin", along with its secret key and
+// capabilities.
+type Entity struct {
+	Name string `json:"entity"`
+	Key  string `json:"key"`
+	Caps Caps   `json:"caps"`
+}
+
+type authList struct {
+	AuthDump []Entity `json:"auth_dump"`
+}
+
+func parseEntity(res commands.Response) (*Entity, error) {
+	keyring := Keyring{}
+	if err := res.NoStatus().Unmarshal(&keyring).End(); err != nil {
+		return nil, err
+	}
+	if len(keyring) == 0 {
+		return nil, errNoEntity
+	}
+	return &keyring[0], nil
+}
+
+func capsCommand(prefix, entity string, caps Caps) map[string]interface{} {
+	m := map[string]interface{}{
+		"prefix": prefix,
+		"entity": entity,
+		"format": "json",
+	}
+	if len(caps) > 0 {
+		m["caps"] = caps.args()
+	}
+	return m
+}
+
+// GetOrCreate returns the user with the given entity name. The user is
+// created with the given capabilities if it does not exist. The command
+// fails if the user exists with capabilities other than the given ones.
+//
+// Similar To:
+//
+//	ceph auth get-or-create <entity> [<caps>...]
+func (aa *Admin) GetOrCreate(entity string, caps Caps) (*Entity, error) {
+	m := capsCommand("auth get-or-create", entity, caps)
+	return parseEntity(commands.MarshalMonCommand(aa.conn, m))
+}
+
+// Get returns the user with the given entity name.
+//
+// Similar To:
+//
+//	ceph auth get <entity>
+func (aa *Admin) Get(entity string) (*Entity, error) {
+	m := map[string]string{
+		"prefix": "auth get",
+		"entity": entity,
+		"format": "json",
+	}
+	return parseEntity(commands.MarshalMonCommand(aa.conn, m).FilterPrefix(exportedKeyringPrefix))
+}
+
+// SetCaps replaces all the capabilities of the user with the given entity
+// name with the given capabilities.
+//
+// Similar To:
+//
+//	ceph auth caps <entity> <caps>...
+func (aa *Admin) SetCaps(entity string, caps Caps) error {
+	m := capsCommand("auth caps", entity, caps)
+	return commands.MarshalMonCommand(aa.conn, m).NoBody().End()
+}
+
+func parseList(res commands.Response) ([]Entity, error) {
+	l := authList{}
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l.AuthDump, nil
+}
+
+// List returns all the users of the cluster, including the ones of the ceph
+// daemons.
+//
+// Similar To:
+//
+//	ceph auth ls
+func (aa *Admin) List() ([]Entity, error) {
+	m := map[string]string{
+		"prefix": "auth ls",
+		"format": "json",
+	}
+	return parseList(commands.MarshalMonCommand(aa.conn, m))
+}
+
+// Remove the user with the given entity name.
+//
+// Similar To:
+//
+//	ceph auth rm <entity>
+func (aa *Admin) Remove(entity string) error {
+	m := map[string]string{
+		"prefix": "auth rm",
+		"entity": entity,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(aa.conn, m).NoBody().End()
+}
+
+func parseKey(res commands.Response) (string, error) {
+	k := struct {
+		Key string `json:"key"`
+	}{}
+	if err := res.NoStatus().Unmarshal(&k).End(); err != nil {
+		return "", err
+	}
+	return k.Key, nil
+}
+
+// PrintKey returns the secret key of the user with the given entity name.
+//
+// Similar To:
+//
+//	ceph auth print-key <entity>
+func (aa *Admin) PrintKey(entity string) (string, error) {
+	m := map[string]string{
+		"prefix": "auth print-key",
+		"entity": entity,
+		"format": "json",
+	}
+	return parseKey(commands.MarshalMonCommand(aa.conn, m))
+}
@@ -0,0 +1,165 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var authGetJSON = `
+[
+  {
+    "entity": "client.tenant1",
+    "key": "AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==",
+    "caps": {
+      "mon": "profile rbd",
+      "osd": "profile rbd pool=tenant1"
+    }
+  }
+]
+`
+
+var authLsJSON = `
+{
+  "auth_dump": [
+    {
+      "entity": "osd.0",
+      "key": "AQAP3MhkxLbYIBAAbN0Uj1gZ3ySntZNWdSL9Ew==",
+      "caps": {
+        "mgr": "allow profile osd",
+        "mon": "allow profile osd",
+        "osd": "allow *"
+      }
+    },
+    {
+      "entity": "client.admin",
+      "key": "AQAP3MhkKoEeFhAA3x1F7Zf1vO5lMxMhFV8iWg==",
+      "caps": {
+        "mds": "allow *",
+        "mgr": "allow *",
+        "mon": "allow *",
+        "osd": "allow *"
+      }
+    }
+  ]
+}
+`
+
+func TestParseEntity(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(authGetJSON), "", nil)
+		e, err := parseEntity(r)
+		require.NoError(t, err)
+		assert.Equal(t, "client.tenant1", e.Name)
+		assert.Equal(t, "AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==", e.Key)
+		assert.Equal(t, Caps{
+			"mon": "profile rbd",
+			"osd": "profile rbd pool=tenant1",
+		}, e.Caps)
+	})
+	t.Run("exportedStatus", func(t *testing.T) {
+		r := commands.NewResponse([]byte(authGetJSON), "exported keyring for client.tenant1", nil).
+			FilterPrefix(exportedKeyringPrefix)
+		e, err := parseEntity(r)
+		require.NoError(t, err)
+		assert.Equal(t, "client.tenant1", e.Name)
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[]`), "", nil)
+		_, err := parseEntity(r)
+		assert.Equal(t, errNoEntity, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		e, err := parseEntity(r)
+		assert.Error(t, err)
+		assert.Nil(t, e)
+	})
+}
+
+func TestParseList(t *testing.T) {
+	r := commands.NewResponse([]byte(authLsJSON), "", nil)
+	l, err := parseList(r)
+	require.NoError(t, err)
+	require.Len(t, l, 2)
+	assert.Equal(t, "osd.0", l[0].Name)
+	assert.Equal(t, "allow *", l[1].Caps[DaemonMDS])
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	l, err = parseList(r)
+	assert.Error(t, err)
+	assert.Nil(t, l)
+}
+
+func TestParseKey(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"key":"AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ=="}`), "", nil)
+	k, err := parseKey(r)
+	require.NoError(t, err)
+	assert.Equal(t, "AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==", k)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	_, err = parseKey(r)
+	assert.Error(t, err)
+}
+
+func TestEntityLifecycle(t *testing.T) {
+	aa := getAdmin(t)
+	name := "client.go-ceph-auth-test"
+	caps := NewCaps().
+		Mon(MonCap{Perm: PermR}).
+		OSD(OSDCap{Perm: PermRW, Pool: "go-ceph-auth-test"})
+
+	e, err := aa.GetOrCreate(name, caps)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, aa.Remove(name))
+	})
+	assert.Equal(t, name, e.Name)
+	assert.NotEmpty(t, e.Key)
+	assert.Equal(t, caps, e.Caps)
+
+	e2, err := aa.GetOrCreate(name, caps)
+	require.NoError(t, err)
+	assert.Equal(t, e.Key, e2.Key)
+
+	e2, err = aa.Get(name)
+	require.NoError(t, err)
+	assert.Equal(t, e, e2)
+
+	key, err := aa.PrintKey(name)
+	require.NoError(t, err)
+	assert.Equal(t, e.Key, key)
+
+	caps = NewCaps().Mon(MonCap{Profile: "rbd"})
+	err = aa.SetCaps(name, caps)
+	require.NoError(t, err)
+	e2, err = aa.Get(name)
+	require.NoError(t, err)
+	assert.Equal(t, caps, e2.Caps)
+
+	l, err := aa.List()
+	require.NoError(t, err)
+	found := false
+	for _, e := range l {
+		if e.Name == name {
+			found = true
+			assert.Equal(t, key, e.Key)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestGetMissing(t *testing.T) {
+	aa := getAdmin(t)
+	_, err := aa.Get("client.go-ceph-missing")
+	assert.Error(t, err)
+	_, err = aa.PrintKey("client.go-ceph-missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"sort"
+	"strings"
+)
+
+// Daemon types that capabilities are granted for.
+const (
+	// DaemonMon is the daemon type of the monitors.
+	DaemonMon = "mon"
+	// DaemonOSD is the daemon type of the OSDs.
+	DaemonOSD = "osd"
+	// DaemonMDS is the daemon type of the metadata servers.
+	DaemonMDS = "mds"
+	// DaemonMgr is the daemon type of the managers.
+	DaemonMgr = "mgr"
+)
+
+const capsSeparator = ", "
+
+// Perm is the access granted by a capability.
+type Perm string
+
+const (
+	// PermR grants read access.
+	PermR = Perm("r")
+	// PermRW grants read and write access.
+	PermRW = Perm("rw")
+	// PermRWX grants read and write access as well as the permission to
+	// call class methods.
+	PermRWX = Perm("rwx")
+	// PermAll grants all access.
+	PermAll = Perm("*")
+)
+
+// Caps maps the daemon types to the capabilities granted for them, like
+// "mon" to "allow r". The Mon, OSD, MDS and Mgr methods build the
+// capabilities of a daemon type from typed values.
+type Caps map[string]string
+
+// NewCaps returns a new, empty, Caps.
+func NewCaps() Caps {
+	return Caps{}
+}
+
+// args returns the capabilities as the alternating list of daemon types and
+// capabilities that the auth commands expect.
+func (c Caps) args() []string {
+	types := make([]string, 0, len(c))
+	for t := range c {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	args := make([]string, 0, 2*len(c))
+	for _, t := range types {
+		args = append(args, t, c[t])
+	}
+	return args
+}
+
+func grant(perm Perm, profile string, match ...string) string {
+	s := []string{"allow", string(perm)}
+	if profile != "" {
+		s = []string{"profile", profile}
+	}
+	for _, m := range match {
+		if m != "" {
+			s = append(s, m)
+		}
+	}
+	return strings.Join(s, " ")
+}
+
+func optional(prefix, value string) string {
+	if value == "" {
+		return ""
+	}
+	return prefix + value
+}
+
+// MonCap is a capability granted for the monitors.
+type MonCap struct {
+	// Perm is the access granted, unless Profile is set.
+	Perm Perm
+	// Profile, if set, grants the access of the named profile, like "rbd".
+	Profile string
+}
+
+// String returns the capability in the format expected by ceph.
+func (c MonCap) String() string {
+	return grant(c.Perm, c.Profile)
+}
+
+// Mon sets the capabilities granted for the monitors and returns the Caps.
+func (c Caps) Mon(caps ...MonCap) Caps {
+	s := make([]string, len(caps))
+	for i := range caps {
+		s[i] = caps[i].String()
+	}
+	c[DaemonMon] = strings.Join(s, capsSeparator)
+	return c
+}
+
+// OSDCap is a capability granted for the OSDs.
+type OSDCap struct {
+	// Perm is the access granted, unless Profile is set.
+	Perm Perm
+	// Profile, if set, grants the access of the named profile, like "rbd".
+	Profile string
+	// Pool limits the capability to the named pool.
+	Pool string
+	// Namespace limits the capability to the named namespace.
+	Namespace string
+	// ObjectPrefix limits the capability to the objects whose names start
+	// with the prefix.
+	ObjectPrefix string
+}
+
+// String returns the capability in the format expected by ceph.
+func (c OSDCap) String() string {
+	return grant(c.Perm, c.Profile,
+		optional("pool=", c.Pool),
+		optional("namespace=", c.Namespace),
+		optional("object_prefix ", c.ObjectPrefix))
+}
+
+// OSD sets the capabilities granted for the OSDs and returns the Caps.
+func (c Caps) OSD(caps ...OSDCap) Caps {
+	s := make([]string, len(caps))
+	for i := range caps {
+		s[i] = caps[i].String()
+	}
+	c[DaemonOSD] = strings.Join(s, capsSeparator)
+	return c
+}
+
+// MDSCap is a capability granted for the metadata servers.
+type MDSCap struct {
+	// Perm is the access granted. Perms specific to the metadata servers,
+	// like "rwp", can be used too.
+	Perm Perm
+	// FSName limits the capability to the named file system.
+	FSName string
+	// Path limits the capability to the path of the file system.
+	Path string
+	// RootSquash denies the write access of the root user.
+	RootSquash bool
+}
+
+// String returns the capability in the format expected by ceph.
+func (c MDSCap) String() string {
+	rootSquash := ""
+	if c.RootSquash {
+		rootSquash = "root_squash"
+	}
+	return grant(c.Perm, "",
+		optional("fsname=", c.FSName),
+		optional("path=", c.Path),
+		rootSquash)
+}
+
+// MDS sets the capabilities granted for the metadata servers and returns
+// the Caps.
+func (c Caps) MDS(caps ...MDSCap) Caps {
+	s := make([]string, len(caps))
+	for i := range caps {
+		s[i] = caps[i].String()
+	}
+	c[DaemonMDS] = strings.Join(s, capsSeparator)
+	return c
+}
+
+// MgrCap is a capability granted for the managers.
+type MgrCap struct {
+	// Perm is the access granted, unless Profile is set.
+	Perm Perm
+	// Profile, if set, grants the access of the named profile, like "rbd".
+	Profile string
+	// Pool limits the access of the profile to the named pool.
+	Pool string
+	// Namespace limits the access of the profile to the named namespace.
+	Namespace string
+}
+
+// String returns the capability in the format expected by ceph.
+func (c MgrCap) String() string {
+	return grant(c.Perm, c.Profile,
+		optional("pool=", c.Pool),
+		optional("namespace=", c.Namespace))
+}
+
+// Mgr sets the capabilities granted for the managers and returns the Caps.
+func (c Caps) Mgr(caps ...MgrCap) Caps {
+	s := make([]string, len(caps))
+	for i := range caps {
+		s[i] = caps[i].String()
+	}
+	c[DaemonMgr] = strings.Join(s, capsSeparator)
+	return c
+}
@@ -0,0 +1,53 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCaps(t *testing.T) {
+	t.Run("rbd", func(t *testing.T) {
+		c := NewCaps().
+			Mon(MonCap{Profile: "rbd"}).
+			OSD(
+				OSDCap{Profile: "rbd", Pool: "images"},
+				OSDCap{Profile: "rbd-read-only", Pool: "templates", Namespace: "ns1"},
+			).
+			Mgr(MgrCap{Profile: "rbd", Pool: "images"})
+		assert.Equal(t, Caps{
+			"mon": "profile rbd",
+			"osd": "profile rbd pool=images, profile rbd-read-only pool=templates namespace=ns1",
+			"mgr": "profile rbd pool=images",
+		}, c)
+		assert.Equal(t, []string{
+			"mgr", "profile rbd pool=images",
+			"mon", "profile rbd",
+			"osd", "profile rbd pool=images, profile rbd-read-only pool=templates namespace=ns1",
+		}, c.args())
+	})
+	t.Run("cephfs", func(t *testing.T) {
+		c := NewCaps().
+			Mon(MonCap{Perm: PermR}).
+			MDS(
+				MDSCap{Perm: PermR},
+				MDSCap{Perm: Perm("rwp"), FSName: "a", Path: "/volumes/t1", RootSquash: true},
+			).
+			OSD(OSDCap{Perm: PermRWX, Pool: "cephfs_data", ObjectPrefix: "t1"})
+		assert.Equal(t, Caps{
+			"mon": "allow r",
+			"mds": "allow r, allow rwp fsname=a path=/volumes/t1 root_squash",
+			"osd": "allow rwx pool=cephfs_data object_prefix t1",
+		}, c)
+	})
+	t.Run("raw", func(t *testing.T) {
+		c := Caps{DaemonMon: "allow *"}.Mgr(MgrCap{Perm: PermAll})
+		assert.Equal(t, []string{"mgr", "allow *", "mon", "allow *"}, c.args())
+	})
+	t.Run("empty", func(t *testing.T) {
+		assert.Len(t, NewCaps().args(), 0)
+	})
+}
@@ -0,0 +1,6 @@
+/*
+Package auth from common/admin contains a set of APIs used to manage the
+cephx users of a Ceph cluster, their keys and capabilities, as well as to
+read and write keyring files.
+*/
+package auth
@@ -0,0 +1,98 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const capsKeyPrefix = "caps "
+
+// Keyring is a list of users along with their keys and capabilities, as
+// stored in a keyring file.
+type Keyring []Entity
+
+// Encode writes the keyring to w in the INI format of the keyring files
+// read by ceph.
+func (k Keyring) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for _, e := range k {
+		fmt.Fprintf(bw, "[%s]\n", e.Name)
+		fmt.Fprintf(bw, "\tkey = %s\n", e.Key)
+		types := make([]string, 0, len(e.Caps))
+		for t := range e.Caps {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		for _, t := range types {
+			fmt.Fprintf(bw, "\t%s%s = %s\n", capsKeyPrefix, t, quote(e.Caps[t]))
+		}
+	}
+	return bw.Flush()
+}
+
+// quote quotes the value unless it contains quotes itself, like ceph does.
+func quote(v string) string {
+	if strings.Contains(v, `"`) {
+		return v
+	}
+	return `"` + v + `"`
+}
+
+func unquote(v string) string {
+	if len(v) >= 2 && v[0] == '"' && v[len(v)-1] == '"' &&
+		!strings.Contains(v[1:len(v)-1], `"`) {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// DecodeKeyring reads a keyring in the INI format of the keyring files read
+// by ceph from r. Settings other than the keys and capabilities of the users
+// are ignored.
+func DecodeKeyring(r io.Reader) (Keyring, error) {
+	keyring := Keyring{}
+	var current *Entity
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || line[0] == '#' || line[0] == ';':
+			continue
+		case line[0] == '[':
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("invalid keyring section on line %d: %q", n, line)
+			}
+			keyring = append(keyring, Entity{
+				Name: strings.TrimSpace(line[1 : len(line)-1]),
+				Caps: Caps{},
+			})
+			current = &keyring[len(keyring)-1]
+			continue
+		case current == nil:
+			return nil, fmt.Errorf("keyring setting outside of a section on line %d: %q", n, line)
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid keyring setting on line %d: %q", n, line)
+		}
+		name = strings.Join(strings.Fields(name), " ")
+		value = unquote(strings.TrimSpace(value))
+		switch {
+		case name == "key":
+			current.Key = value
+		case strings.HasPrefix(name, capsKeyPrefix):
+			current.Caps[strings.TrimPrefix(name, capsKeyPrefix)] = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keyring, nil
+}
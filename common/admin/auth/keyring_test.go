@@ -0,0 +1,84 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package auth
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var keyringText = `[client.admin]
+	key = AQAP3MhkKoEeFhAA3x1F7Zf1vO5lMxMhFV8iWg==
+	caps mds = "allow *"
+	caps mon = "allow *"
+	caps osd = allow command "osd blocklist"
+[client.tenant1]
+	key = AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==
+`
+
+func TestKeyringEncode(t *testing.T) {
+	k := Keyring{
+		{
+			Name: "client.admin",
+			Key:  "AQAP3MhkKoEeFhAA3x1F7Zf1vO5lMxMhFV8iWg==",
+			Caps: Caps{
+				"osd": `allow command "osd blocklist"`,
+				"mon": "allow *",
+				"mds": "allow *",
+			},
+		},
+		{
+			Name: "client.tenant1",
+			Key:  "AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==",
+		},
+	}
+	buf := &bytes.Buffer{}
+	err := k.Encode(buf)
+	require.NoError(t, err)
+	assert.Equal(t, keyringText, buf.String())
+
+	k2, err := DecodeKeyring(buf)
+	require.NoError(t, err)
+	k[1].Caps = Caps{}
+	assert.Equal(t, k, k2)
+}
+
+func TestDecodeKeyring(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		k, err := DecodeKeyring(strings.NewReader(`
+# written by hand
+[ client.foo ]
+key=AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==
+  caps  mon = "profile rbd"
+; auid is not supported anymore
+auid = 0
+`))
+		require.NoError(t, err)
+		require.Len(t, k, 1)
+		assert.Equal(t, "client.foo", k[0].Name)
+		assert.Equal(t, "AQBJ3MhkpD1RJBAAqMTmrEhGY/OQk6Rb5cAPNQ==", k[0].Key)
+		assert.Equal(t, Caps{"mon": "profile rbd"}, k[0].Caps)
+	})
+	t.Run("empty", func(t *testing.T) {
+		k, err := DecodeKeyring(strings.NewReader(""))
+		require.NoError(t, err)
+		assert.Len(t, k, 0)
+	})
+	t.Run("noSection", func(t *testing.T) {
+		_, err := DecodeKeyring(strings.NewReader("key = foo\n"))
+		assert.Error(t, err)
+	})
+	t.Run("badSection", func(t *testing.T) {
+		_, err := DecodeKeyring(strings.NewReader("[client.foo\n"))
+		assert.Error(t, err)
+	})
+	t.Run("badSetting", func(t *testing.T) {
+		_, err := DecodeKeyring(strings.NewReader("[client.foo]\nkey\n"))
+		assert.Error(t, err)
+	})
+}
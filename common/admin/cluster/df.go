@@ -0,0 +1,81 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DfStats reports the raw capacity and usage of the OSDs of the cluster or
+// of a device class.
+type DfStats struct {
+	TotalBytes        uint64  `json:"total_bytes"`
+	TotalAvailBytes   uint64  `json:"total_avail_bytes"`
+	TotalUsedBytes    uint64  `json:"total_used_bytes"`
+	TotalUsedRawBytes uint64  `json:"total_used_raw_bytes"`
+	TotalUsedRawRatio float64 `json:"total_used_raw_ratio"`
+	NumOSDs           int     `json:"num_osds"`
+}
+
+// DfPoolStats reports the usage of a pool. The fields after MaxAvail are
+// only reported if the details were requested.
+type DfPoolStats struct {
+	Stored             uint64  `json:"stored"`
+	Objects            uint64  `json:"objects"`
+	KBUsed             uint64  `json:"kb_used"`
+	BytesUsed          uint64  `json:"bytes_used"`
+	PercentUsed        float64 `json:"percent_used"`
+	MaxAvail           uint64  `json:"max_avail"`
+	QuotaObjects       uint64  `json:"quota_objects"`
+	QuotaBytes         uint64  `json:"quota_bytes"`
+	Dirty              uint64  `json:"dirty"`
+	Rd                 uint64  `json:"rd"`
+	RdBytes            uint64  `json:"rd_bytes"`
+	Wr                 uint64  `json:"wr"`
+	WrBytes            uint64  `json:"wr_bytes"`
+	CompressBytesUsed  uint64  `json:"compress_bytes_used"`
+	CompressUnderBytes uint64  `json:"compress_under_bytes"`
+	StoredRaw          uint64  `json:"stored_raw"`
+	AvailRaw           uint64  `json:"avail_raw"`
+}
+
+// DfPool reports the usage of a pool.
+type DfPool struct {
+	Name  string      `json:"name"`
+	ID    int64       `json:"id"`
+	Stats DfPoolStats `json:"stats"`
+}
+
+// Df reports the capacity and usage of the cluster and its pools.
+type Df struct {
+	Stats        DfStats            `json:"stats"`
+	StatsByClass map[string]DfStats `json:"stats_by_class"`
+	Pools        []DfPool           `json:"pools"`
+}
+
+func parseDf(res commands.Response) (*Df, error) {
+	df := &Df{}
+	if err := res.NoStatus().Unmarshal(df).End(); err != nil {
+		return nil, err
+	}
+	return df, nil
+}
+
+// Df returns the capacity and usage of the cluster and its pools. If detail
+// is true the I/O statistics, quotas and compression of the pools are
+// reported too.
+//
+// Similar To:
+//
+//	ceph df [detail]
+func (ca *Admin) Df(detail bool) (*Df, error) {
+	m := map[string]string{
+		"prefix": "df",
+		"format": "json",
+	}
+	if detail {
+		m["detail"] = "detail"
+	}
+	return parseDf(commands.MarshalMonCommand(ca.conn, m))
+}
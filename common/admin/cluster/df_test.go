@@ -0,0 +1,103 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var dfDetailJSON = `
+{
+  "stats": {
+    "total_bytes": 21474836480,
+    "total_avail_bytes": 20375257088,
+    "total_used_bytes": 1099579392,
+    "total_used_raw_bytes": 1099579392,
+    "total_used_raw_ratio": 0.051203727722167969,
+    "num_osds": 1,
+    "num_per_pool_osds": 1,
+    "num_per_pool_omap_osds": 1
+  },
+  "stats_by_class": {
+    "hdd": {
+      "total_bytes": 21474836480,
+      "total_avail_bytes": 20375257088,
+      "total_used_bytes": 1099579392,
+      "total_used_raw_bytes": 1099579392,
+      "total_used_raw_ratio": 0.051203727722167969
+    }
+  },
+  "pools": [
+    {
+      "name": "rbd",
+      "id": 2,
+      "stats": {
+        "stored": 590368,
+        "stored_data": 590368,
+        "stored_omap": 0,
+        "objects": 12,
+        "kb_used": 600,
+        "bytes_used": 614400,
+        "data_bytes_used": 614400,
+        "omap_bytes_used": 0,
+        "percent_used": 3.0154702160507441e-05,
+        "max_avail": 20374642688,
+        "quota_objects": 100,
+        "quota_bytes": 0,
+        "dirty": 12,
+        "rd": 15,
+        "rd_bytes": 4096,
+        "wr": 27,
+        "wr_bytes": 600000,
+        "compress_bytes_used": 0,
+        "compress_under_bytes": 0,
+        "stored_raw": 590368,
+        "avail_raw": 20374642688
+      }
+    }
+  ]
+}
+`
+
+func TestParseDf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(dfDetailJSON), "", nil)
+		df, err := parseDf(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, 21474836480, df.Stats.TotalBytes)
+		assert.Equal(t, 1, df.Stats.NumOSDs)
+		assert.Contains(t, df.StatsByClass, "hdd")
+		require.Len(t, df.Pools, 1)
+		assert.Equal(t, "rbd", df.Pools[0].Name)
+		assert.EqualValues(t, 12, df.Pools[0].Stats.Objects)
+		assert.EqualValues(t, 100, df.Pools[0].Stats.QuotaObjects)
+		assert.EqualValues(t, 27, df.Pools[0].Stats.Wr)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		df, err := parseDf(r)
+		assert.Error(t, err)
+		assert.Nil(t, df)
+	})
+}
+
+func TestDf(t *testing.T) {
+	ca := getAdmin(t)
+
+	df, err := ca.Df(false)
+	require.NoError(t, err)
+	assert.Greater(t, df.Stats.TotalBytes, uint64(0))
+	assert.NotEmpty(t, df.Pools)
+
+	df, err = ca.Df(true)
+	require.NoError(t, err)
+	assert.Greater(t, df.Stats.TotalBytes, uint64(0))
+	assert.NotEmpty(t, df.Pools)
+}
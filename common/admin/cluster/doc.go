@@ -0,0 +1,6 @@
+/*
+Package cluster from common/admin contains a set of APIs used to query the
+health, status and usage of a Ceph cluster as well as the state of its
+monitors.
+*/
+package cluster
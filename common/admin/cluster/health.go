@@ -0,0 +1,85 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// HealthStatus is the health of the cluster or the severity of a health
+// check.
+type HealthStatus string
+
+const (
+	// HealthOK indicates a healthy cluster.
+	HealthOK = HealthStatus("HEALTH_OK")
+	// HealthWarn indicates a warning.
+	HealthWarn = HealthStatus("HEALTH_WARN")
+	// HealthErr indicates an error that needs immediate attention.
+	HealthErr = HealthStatus("HEALTH_ERR")
+)
+
+// HealthSummary summarizes a failed health check.
+type HealthSummary struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// HealthDetail is a detail message of a failed health check.
+type HealthDetail struct {
+	Message string `json:"message"`
+}
+
+// HealthCheck is a failed health check.
+type HealthCheck struct {
+	Severity HealthStatus  `json:"severity"`
+	Summary  HealthSummary `json:"summary"`
+	// Detail is only reported by HealthDetail.
+	Detail []HealthDetail `json:"detail"`
+	Muted  bool           `json:"muted"`
+}
+
+// Health is the health of the cluster along with the failed health checks,
+// indexed by their codes, like "OSD_DOWN".
+type Health struct {
+	Status HealthStatus           `json:"status"`
+	Checks map[string]HealthCheck `json:"checks"`
+}
+
+func parseHealth(res commands.Response) (*Health, error) {
+	h := &Health{}
+	if err := res.NoStatus().Unmarshal(h).End(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Health returns the health of the cluster along with the summaries of the
+// failed health checks.
+//
+// Similar To:
+//
+//	ceph health
+func (ca *Admin) Health() (*Health, error) {
+	m := map[string]string{
+		"prefix": "health",
+		"format": "json",
+	}
+	return parseHealth(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// HealthDetail returns the health of the cluster including the detail
+// messages of the failed health checks.
+//
+// Similar To:
+//
+//	ceph health detail
+func (ca *Admin) HealthDetail() (*Health, error) {
+	m := map[string]string{
+		"prefix": "health",
+		"detail": "detail",
+		"format": "json",
+	}
+	return parseHealth(commands.MarshalMonCommand(ca.conn, m))
+}
@@ -0,0 +1,103 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var healthDetailJSON = `
+{
+  "status": "HEALTH_WARN",
+  "checks": {
+    "OSD_DOWN": {
+      "severity": "HEALTH_WARN",
+      "summary": {
+        "message": "1 osds down",
+        "count": 1
+      },
+      "detail": [
+        {
+          "message": "osd.1 (root=default,host=node1) is down"
+        }
+      ],
+      "muted": false
+    },
+    "POOL_NO_REDUNDANCY": {
+      "severity": "HEALTH_WARN",
+      "summary": {
+        "message": "2 pool(s) have no replicas configured",
+        "count": 2
+      },
+      "detail": [
+        {
+          "message": "pool '.mgr' has no replicas configured"
+        },
+        {
+          "message": "pool 'rbd' has no replicas configured"
+        }
+      ],
+      "muted": true
+    }
+  },
+  "mutes": []
+}
+`
+
+func TestParseHealth(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(healthDetailJSON), "", nil)
+		h, err := parseHealth(r)
+		require.NoError(t, err)
+		assert.Equal(t, HealthWarn, h.Status)
+		require.Len(t, h.Checks, 2)
+		c := h.Checks["OSD_DOWN"]
+		assert.Equal(t, HealthWarn, c.Severity)
+		assert.Equal(t, "1 osds down", c.Summary.Message)
+		assert.Equal(t, 1, c.Summary.Count)
+		require.Len(t, c.Detail, 1)
+		assert.Contains(t, c.Detail[0].Message, "osd.1")
+		assert.False(t, c.Muted)
+		c = h.Checks["POOL_NO_REDUNDANCY"]
+		assert.Len(t, c.Detail, 2)
+		assert.True(t, c.Muted)
+	})
+	t.Run("ok", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`{"status":"HEALTH_OK","checks":{},"mutes":[]}`), "", nil)
+		h, err := parseHealth(r)
+		require.NoError(t, err)
+		assert.Equal(t, HealthOK, h.Status)
+		assert.Len(t, h.Checks, 0)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		h, err := parseHealth(r)
+		assert.Error(t, err)
+		assert.Nil(t, h)
+	})
+}
+
+func TestHealth(t *testing.T) {
+	ca := getAdmin(t)
+
+	h, err := ca.Health()
+	require.NoError(t, err)
+	assert.Contains(t, []HealthStatus{HealthOK, HealthWarn, HealthErr}, h.Status)
+	for _, c := range h.Checks {
+		assert.Empty(t, c.Detail)
+	}
+
+	hd, err := ca.HealthDetail()
+	require.NoError(t, err)
+	assert.Contains(t, []HealthStatus{HealthOK, HealthWarn, HealthErr}, hd.Status)
+	for code, c := range hd.Checks {
+		assert.NotEmpty(t, c.Summary.Message, code)
+	}
+}
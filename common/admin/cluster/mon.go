@@ -0,0 +1,98 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// EntityAddr is one of the addresses of a ceph daemon.
+type EntityAddr struct {
+	Type  string `json:"type"`
+	Addr  string `json:"addr"`
+	Nonce uint64 `json:"nonce"`
+}
+
+// EntityAddrVec is the set of addresses of a ceph daemon.
+type EntityAddrVec struct {
+	AddrVec []EntityAddr `json:"addrvec"`
+}
+
+// MonInfo describes a monitor of the monitor map.
+type MonInfo struct {
+	Rank        int           `json:"rank"`
+	Name        string        `json:"name"`
+	PublicAddrs EntityAddrVec `json:"public_addrs"`
+	Addr        string        `json:"addr"`
+	PublicAddr  string        `json:"public_addr"`
+	Priority    int           `json:"priority"`
+	Weight      int           `json:"weight"`
+}
+
+// MonMap is the monitor map of the cluster.
+type MonMap struct {
+	Epoch             uint64    `json:"epoch"`
+	FSID              string    `json:"fsid"`
+	Modified          string    `json:"modified"`
+	Created           string    `json:"created"`
+	MinMonRelease     int       `json:"min_mon_release"`
+	MinMonReleaseName string    `json:"min_mon_release_name"`
+	Mons              []MonInfo `json:"mons"`
+	Quorum            []int     `json:"quorum"`
+}
+
+// QuorumStatus is the state of the quorum of the monitors.
+type QuorumStatus struct {
+	ElectionEpoch    uint64   `json:"election_epoch"`
+	Quorum           []int    `json:"quorum"`
+	QuorumNames      []string `json:"quorum_names"`
+	QuorumLeaderName string   `json:"quorum_leader_name"`
+	QuorumAge        int64    `json:"quorum_age"`
+	MonMap           MonMap   `json:"monmap"`
+}
+
+func parseQuorumStatus(res commands.Response) (*QuorumStatus, error) {
+	q := &QuorumStatus{}
+	if err := res.NoStatus().Unmarshal(q).End(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// QuorumStatus returns the state of the quorum of the monitors.
+//
+// Similar To:
+//
+//	ceph quorum_status
+func (ca *Admin) QuorumStatus() (*QuorumStatus, error) {
+	m := map[string]string{
+		"prefix": "quorum_status",
+		"format": "json",
+	}
+	return parseQuorumStatus(commands.MarshalMonCommand(ca.conn, m))
+}
+
+const monDumpOkPrefix = "dumped monmap epoch"
+
+func parseMonMap(res commands.Response) (*MonMap, error) {
+	mm := &MonMap{}
+	err := res.FilterPrefix(monDumpOkPrefix).NoStatus().Unmarshal(mm).End()
+	if err != nil {
+		return nil, err
+	}
+	return mm, nil
+}
+
+// MonDump returns the monitor map of the cluster.
+//
+// Similar To:
+//
+//	ceph mon dump
+func (ca *Admin) MonDump() (*MonMap, error) {
+	m := map[string]string{
+		"prefix": "mon dump",
+		"format": "json",
+	}
+	return parseMonMap(commands.MarshalMonCommand(ca.conn, m))
+}
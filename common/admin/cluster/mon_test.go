@@ -0,0 +1,135 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var monMapJSON = `
+{
+  "epoch": 1,
+  "fsid": "2bd26aa1-1cd4-4bc6-9a5f-6a5f5d1b8d4b",
+  "modified": "2023-08-01T10:03:08.574105Z",
+  "created": "2023-08-01T10:03:08.574105Z",
+  "min_mon_release": 17,
+  "min_mon_release_name": "quincy",
+  "election_strategy": 1,
+  "disallowed_leaders: ": "",
+  "stretch_mode": false,
+  "tiebreaker_mon": "",
+  "removed_ranks: ": "",
+  "features": {
+    "persistent": ["kraken", "luminous", "mimic", "osdmap-prune", "nautilus", "octopus", "pacific", "elector-pinging", "quincy"],
+    "optional": []
+  },
+  "mons": [
+    {
+      "rank": 0,
+      "name": "a",
+      "public_addrs": {
+        "addrvec": [
+          {
+            "type": "v2",
+            "addr": "10.0.0.2:3300",
+            "nonce": 0
+          },
+          {
+            "type": "v1",
+            "addr": "10.0.0.2:6789",
+            "nonce": 0
+          }
+        ]
+      },
+      "addr": "10.0.0.2:6789/0",
+      "public_addr": "10.0.0.2:6789/0",
+      "priority": 0,
+      "weight": 0,
+      "crush_location": "{}"
+    }
+  ],
+  "quorum": [0]
+}
+`
+
+var quorumStatusJSON = `
+{
+  "election_epoch": 3,
+  "quorum": [0],
+  "quorum_names": ["a"],
+  "quorum_leader_name": "a",
+  "quorum_age": 3712,
+  "features": {
+    "quorum_con": "4540138320759226367",
+    "quorum_mon": ["kraken", "luminous", "mimic", "osdmap-prune", "nautilus", "octopus", "pacific", "elector-pinging", "quincy"]
+  },
+  "monmap": ` + monMapJSON + `
+}
+`
+
+func TestParseMonMap(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(monMapJSON), "dumped monmap epoch 1", nil)
+		mm, err := parseMonMap(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, mm.Epoch)
+		assert.Equal(t, "quincy", mm.MinMonReleaseName)
+		assert.Equal(t, 17, mm.MinMonRelease)
+		require.Len(t, mm.Mons, 1)
+		assert.Equal(t, "a", mm.Mons[0].Name)
+		assert.Equal(t, "10.0.0.2:6789/0", mm.Mons[0].PublicAddr)
+		require.Len(t, mm.Mons[0].PublicAddrs.AddrVec, 2)
+		assert.Equal(t, "v2", mm.Mons[0].PublicAddrs.AddrVec[0].Type)
+		assert.Equal(t, "10.0.0.2:3300", mm.Mons[0].PublicAddrs.AddrVec[0].Addr)
+		assert.Equal(t, []int{0}, mm.Quorum)
+	})
+	t.Run("unexpectedStatus", func(t *testing.T) {
+		r := commands.NewResponse([]byte(monMapJSON), "oops", nil)
+		_, err := parseMonMap(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		mm, err := parseMonMap(r)
+		assert.Error(t, err)
+		assert.Nil(t, mm)
+	})
+}
+
+func TestParseQuorumStatus(t *testing.T) {
+	r := commands.NewResponse([]byte(quorumStatusJSON), "", nil)
+	q, err := parseQuorumStatus(r)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, q.ElectionEpoch)
+	assert.Equal(t, []string{"a"}, q.QuorumNames)
+	assert.Equal(t, "a", q.QuorumLeaderName)
+	assert.EqualValues(t, 3712, q.QuorumAge)
+	assert.Len(t, q.MonMap.Mons, 1)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	q, err = parseQuorumStatus(r)
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+func TestMon(t *testing.T) {
+	ca := getAdmin(t)
+
+	mm, err := ca.MonDump()
+	require.NoError(t, err)
+	require.NotEmpty(t, mm.Mons)
+	assert.NotEmpty(t, mm.FSID)
+
+	q, err := ca.QuorumStatus()
+	require.NoError(t, err)
+	assert.NotEmpty(t, q.QuorumLeaderName)
+	assert.Contains(t, q.QuorumNames, mm.Mons[0].Name)
+	assert.Equal(t, mm.FSID, q.MonMap.FSID)
+}
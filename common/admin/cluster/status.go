@@ -0,0 +1,109 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"encoding/json"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// MonMapSummary summarizes the monitor map.
+type MonMapSummary struct {
+	Epoch             uint64 `json:"epoch"`
+	MinMonReleaseName string `json:"min_mon_release_name"`
+	NumMons           int    `json:"num_mons"`
+}
+
+// OSDMapSummary summarizes the OSD map.
+type OSDMapSummary struct {
+	Epoch          uint64 `json:"epoch"`
+	NumOSDs        int    `json:"num_osds"`
+	NumUpOSDs      int    `json:"num_up_osds"`
+	NumInOSDs      int    `json:"num_in_osds"`
+	NumRemappedPGs int    `json:"num_remapped_pgs"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It accepts the
+// summary nested in an "osdmap" object, as reported by older versions of
+// ceph, too.
+func (s *OSDMapSummary) UnmarshalJSON(b []byte) error {
+	type summary OSDMapSummary
+	var nested struct {
+		OSDMap *summary `json:"osdmap"`
+	}
+	if err := json.Unmarshal(b, &nested); err != nil {
+		return err
+	}
+	if nested.OSDMap != nil {
+		*s = OSDMapSummary(*nested.OSDMap)
+		return nil
+	}
+	return json.Unmarshal(b, (*summary)(s))
+}
+
+// PGStateCount is the number of placement groups in a state.
+type PGStateCount struct {
+	StateName string `json:"state_name"`
+	Count     int    `json:"count"`
+}
+
+// PGMapSummary summarizes the placement groups, the usage of the cluster
+// and the client I/O rates.
+type PGMapSummary struct {
+	PGsByState    []PGStateCount `json:"pgs_by_state"`
+	NumPGs        int            `json:"num_pgs"`
+	NumPools      int            `json:"num_pools"`
+	NumObjects    uint64         `json:"num_objects"`
+	DataBytes     uint64         `json:"data_bytes"`
+	BytesUsed     uint64         `json:"bytes_used"`
+	BytesAvail    uint64         `json:"bytes_avail"`
+	BytesTotal    uint64         `json:"bytes_total"`
+	ReadBytesSec  uint64         `json:"read_bytes_sec"`
+	WriteBytesSec uint64         `json:"write_bytes_sec"`
+	ReadOpPerSec  uint64         `json:"read_op_per_sec"`
+	WriteOpPerSec uint64         `json:"write_op_per_sec"`
+}
+
+// MgrMapSummary summarizes the manager map.
+type MgrMapSummary struct {
+	Available   bool     `json:"available"`
+	NumStandbys int      `json:"num_standbys"`
+	Modules     []string `json:"modules"`
+}
+
+// Status is the status of the cluster.
+type Status struct {
+	FSID          string        `json:"fsid"`
+	Health        Health        `json:"health"`
+	ElectionEpoch uint64        `json:"election_epoch"`
+	Quorum        []int         `json:"quorum"`
+	QuorumNames   []string      `json:"quorum_names"`
+	QuorumAge     int64         `json:"quorum_age"`
+	MonMap        MonMapSummary `json:"monmap"`
+	OSDMap        OSDMapSummary `json:"osdmap"`
+	PGMap         PGMapSummary  `json:"pgmap"`
+	MgrMap        MgrMapSummary `json:"mgrmap"`
+}
+
+func parseStatus(res commands.Response) (*Status, error) {
+	s := &Status{}
+	if err := res.NoStatus().Unmarshal(s).End(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Status returns the status of the cluster.
+//
+// Similar To:
+//
+//	ceph status
+func (ca *Admin) Status() (*Status, error) {
+	m := map[string]string{
+		"prefix": "status",
+		"format": "json",
+	}
+	return parseStatus(commands.MarshalMonCommand(ca.conn, m))
+}
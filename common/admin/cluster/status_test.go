@@ -0,0 +1,140 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var statusJSON = `
+{
+  "fsid": "2bd26aa1-1cd4-4bc6-9a5f-6a5f5d1b8d4b",
+  "health": {
+    "status": "HEALTH_OK",
+    "checks": {},
+    "mutes": []
+  },
+  "election_epoch": 3,
+  "quorum": [0],
+  "quorum_names": ["a"],
+  "quorum_age": 3712,
+  "monmap": {
+    "epoch": 1,
+    "min_mon_release_name": "quincy",
+    "num_mons": 1
+  },
+  "osdmap": {
+    "epoch": 21,
+    "num_osds": 1,
+    "num_up_osds": 1,
+    "osd_up_since": 1690884196,
+    "num_in_osds": 1,
+    "osd_in_since": 1690884193,
+    "num_remapped_pgs": 0
+  },
+  "pgmap": {
+    "pgs_by_state": [
+      {
+        "state_name": "active+clean",
+        "count": 33
+      }
+    ],
+    "num_pgs": 33,
+    "num_pools": 2,
+    "num_objects": 12,
+    "data_bytes": 590368,
+    "bytes_used": 1099517952,
+    "bytes_avail": 20375257088,
+    "bytes_total": 21474836480,
+    "read_bytes_sec": 1023,
+    "write_bytes_sec": 4096,
+    "read_op_per_sec": 1,
+    "write_op_per_sec": 2
+  },
+  "fsmap": {
+    "epoch": 1,
+    "by_rank": [],
+    "up:standby": 0
+  },
+  "mgrmap": {
+    "available": true,
+    "num_standbys": 0,
+    "modules": ["iostat", "nfs", "restful"],
+    "services": {}
+  },
+  "servicemap": {
+    "epoch": 2,
+    "modified": "2023-08-01T10:03:45.213481+0000",
+    "services": {}
+  },
+  "progress_events": {}
+}
+`
+
+func TestParseStatus(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(statusJSON), "", nil)
+		s, err := parseStatus(r)
+		require.NoError(t, err)
+		assert.Equal(t, "2bd26aa1-1cd4-4bc6-9a5f-6a5f5d1b8d4b", s.FSID)
+		assert.Equal(t, HealthOK, s.Health.Status)
+		assert.Equal(t, []string{"a"}, s.QuorumNames)
+		assert.Equal(t, 1, s.MonMap.NumMons)
+		assert.EqualValues(t, 21, s.OSDMap.Epoch)
+		assert.Equal(t, 1, s.OSDMap.NumUpOSDs)
+		assert.Equal(t, 33, s.PGMap.NumPGs)
+		require.Len(t, s.PGMap.PGsByState, 1)
+		assert.Equal(t, "active+clean", s.PGMap.PGsByState[0].StateName)
+		assert.EqualValues(t, 4096, s.PGMap.WriteBytesSec)
+		assert.EqualValues(t, 2, s.PGMap.WriteOpPerSec)
+		assert.True(t, s.MgrMap.Available)
+		assert.Contains(t, s.MgrMap.Modules, "nfs")
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		s, err := parseStatus(r)
+		assert.Error(t, err)
+		assert.Nil(t, s)
+	})
+}
+
+func TestOSDMapSummaryNested(t *testing.T) {
+	var s OSDMapSummary
+	err := json.Unmarshal([]byte(`{
+    "osdmap": {
+      "epoch": 18,
+      "num_osds": 3,
+      "num_up_osds": 2,
+      "num_in_osds": 3,
+      "full": false,
+      "nearfull": false,
+      "num_remapped_pgs": 4
+    }
+}`), &s)
+	require.NoError(t, err)
+	assert.EqualValues(t, 18, s.Epoch)
+	assert.Equal(t, 3, s.NumOSDs)
+	assert.Equal(t, 2, s.NumUpOSDs)
+	assert.Equal(t, 4, s.NumRemappedPGs)
+
+	err = json.Unmarshal([]byte(`[]`), &s)
+	assert.Error(t, err)
+}
+
+func TestStatus(t *testing.T) {
+	ca := getAdmin(t)
+	s, err := ca.Status()
+	require.NoError(t, err)
+	assert.NotEmpty(t, s.FSID)
+	assert.NotEmpty(t, s.QuorumNames)
+	assert.Greater(t, s.OSDMap.NumOSDs, 0)
+	assert.Greater(t, s.PGMap.BytesTotal, uint64(0))
+}
@@ -0,0 +1,36 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Versions maps the daemon types, like "mon" and "osd", and "overall" for
+// all daemons, to the number of daemons running each version of ceph. The
+// versions are the full version strings, for example "ceph version 17.2.6
+// (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)".
+type Versions map[string]map[string]int
+
+func parseVersions(res commands.Response) (Versions, error) {
+	v := Versions{}
+	if err := res.NoStatus().Unmarshal(&v).End(); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Versions returns the versions of ceph running on the daemons of the
+// cluster.
+//
+// Similar To:
+//
+//	ceph versions
+func (ca *Admin) Versions() (Versions, error) {
+	m := map[string]string{
+		"prefix": "versions",
+		"format": "json",
+	}
+	return parseVersions(commands.MarshalMonCommand(ca.conn, m))
+}
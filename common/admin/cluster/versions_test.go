@@ -0,0 +1,56 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var versionsJSON = `
+{
+  "mon": {
+    "ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)": 1
+  },
+  "mgr": {
+    "ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)": 1
+  },
+  "osd": {
+    "ceph version 17.2.5 (98318ae89f1a893a6ded3a640405cdbb33e08757) quincy (stable)": 1,
+    "ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)": 2
+  },
+  "mds": {},
+  "overall": {
+    "ceph version 17.2.5 (98318ae89f1a893a6ded3a640405cdbb33e08757) quincy (stable)": 1,
+    "ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)": 4
+  }
+}
+`
+
+func TestParseVersions(t *testing.T) {
+	r := commands.NewResponse([]byte(versionsJSON), "", nil)
+	v, err := parseVersions(r)
+	require.NoError(t, err)
+	assert.Len(t, v["osd"], 2)
+	assert.Len(t, v["mds"], 0)
+	assert.Equal(t, 4, v["overall"]["ceph version 17.2.6 (d7ff0d10654d2280e08f1ab989c7cdf3064446a5) quincy (stable)"])
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	v, err = parseVersions(r)
+	assert.Error(t, err)
+	assert.Nil(t, v)
+}
+
+func TestVersions(t *testing.T) {
+	ca := getAdmin(t)
+	v, err := ca.Versions()
+	require.NoError(t, err)
+	assert.NotEmpty(t, v["mon"])
+	assert.NotEmpty(t, v["overall"])
+}
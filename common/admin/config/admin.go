@@ -0,0 +1,33 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	ccom "github.com/ceph/go-ceph/common/commands"
+)
+
+// Admin is used to administer the configuration of a ceph cluster.
+type Admin struct {
+	conn ccom.RadosCommander
+	// bufConn sends the mon commands that take an input buffer, it is nil
+	// if the Admin was not created by NewFromInputBufferConn
+	bufConn ccom.MonInputBufferCommander
+}
+
+// NewFromConn creates an new management object from a preexisting
+// rados connection. The existing connection can be rados.Conn or any
+// type implementing the RadosCommander interface. The Admin can not send
+// commands that take an input buffer, see NewFromInputBufferConn.
+func NewFromConn(conn ccom.RadosCommander) *Admin {
+	return &Admin{conn: conn}
+}
+
+// NewFromInputBufferConn creates an new management object from a
+// preexisting rados connection that can also send mon commands with an
+// input buffer, as needed by AssimilateConf. The existing connection can be
+// rados.Conn or any type implementing the RadosInputBufferCommander
+// interface.
+func NewFromInputBufferConn(conn ccom.RadosInputBufferCommander) *Admin {
+	return &Admin{conn: conn, bufConn: conn}
+}
@@ -0,0 +1,16 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"testing"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func getAdmin(t *testing.T) *Admin {
+	return NewFromConn(radosConnector.Get(t))
+}
@@ -0,0 +1,174 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var errNoInputBuffer = errors.New("connection can not send commands with an input buffer")
+
+// Option is an option stored in the configuration database.
+type Option struct {
+	Section            string `json:"section"`
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Level              string `json:"level"`
+	CanUpdateAtRuntime bool   `json:"can_update_at_runtime"`
+	Mask               string `json:"mask"`
+	LocationType       string `json:"location_type"`
+	LocationValue      string `json:"location_value"`
+}
+
+// Who returns the masked section the option is set for.
+func (o Option) Who() Who {
+	w := Who{
+		Section:       o.Section,
+		LocationType:  o.LocationType,
+		LocationValue: o.LocationValue,
+	}
+	for _, m := range strings.Split(o.Mask, "/") {
+		if strings.HasPrefix(m, classMaskPrefix) {
+			w.DeviceClass = strings.TrimPrefix(m, classMaskPrefix)
+		}
+	}
+	return w
+}
+
+// RunningOption is an option in the running configuration of a daemon.
+type RunningOption struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// Set the option with the given name to the given value for the given
+// daemons or clients.
+//
+// Similar To:
+//
+//	ceph config set <who> <name> <value>
+func (ca *Admin) Set(who Who, name, value string) error {
+	m := map[string]string{
+		"prefix": "config set",
+		"who":    who.String(),
+		"name":   name,
+		"value":  value,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+}
+
+func parseValue(res commands.Response) (string, error) {
+	var v string
+	if err := res.NoStatus().Unmarshal(&v).End(); err != nil {
+		return "", err
+	}
+	return v, nil
+}
+
+// Get returns the value of the option with the given name that applies to
+// the given daemon or client.
+//
+// Similar To:
+//
+//	ceph config get <who> <name>
+func (ca *Admin) Get(who Who, name string) (string, error) {
+	m := map[string]string{
+		"prefix": "config get",
+		"who":    who.String(),
+		"key":    name,
+		"format": "json",
+	}
+	return parseValue(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// Remove the option with the given name set for the given daemons or
+// clients.
+//
+// Similar To:
+//
+//	ceph config rm <who> <name>
+func (ca *Admin) Remove(who Who, name string) error {
+	m := map[string]string{
+		"prefix": "config rm",
+		"who":    who.String(),
+		"name":   name,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+}
+
+func parseOptions(res commands.Response) ([]Option, error) {
+	o := []Option{}
+	if err := res.NoStatus().Unmarshal(&o).End(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Dump returns all the options stored in the configuration database.
+//
+// Similar To:
+//
+//	ceph config dump
+func (ca *Admin) Dump() ([]Option, error) {
+	m := map[string]string{
+		"prefix": "config dump",
+		"format": "json",
+	}
+	return parseOptions(commands.MarshalMonCommand(ca.conn, m))
+}
+
+func parseRunningOptions(res commands.Response) ([]RunningOption, error) {
+	o := []RunningOption{}
+	if err := res.NoStatus().Unmarshal(&o).End(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Show returns the options in the running configuration of the given daemon
+// that differ from their defaults.
+//
+// Similar To:
+//
+//	ceph config show <who>
+func (ca *Admin) Show(who string) ([]RunningOption, error) {
+	m := map[string]string{
+		"prefix": "config show",
+		"who":    who,
+		"format": "json",
+	}
+	return parseRunningOptions(commands.MarshalMonCommand(ca.conn, m))
+}
+
+// AssimilateConf stores the options of the given configuration file, in the
+// ceph.conf format, in the configuration database. It returns a
+// configuration file containing the options that could not be stored. The
+// Admin must be created by NewFromInputBufferConn.
+//
+// Similar To:
+//
+//	ceph config assimilate-conf -i <conf>
+func (ca *Admin) AssimilateConf(conf string) (string, error) {
+	if ca.bufConn == nil {
+		return "", errNoInputBuffer
+	}
+	args, err := json.Marshal(map[string]string{
+		"prefix": "config assimilate-conf",
+	})
+	if err != nil {
+		return "", err
+	}
+	res := commands.NewResponse(ca.bufConn.MonCommandWithInputBuffer(args, []byte(conf)))
+	if err := res.NoStatus().End(); err != nil {
+		return "", err
+	}
+	return string(res.Body()), nil
+}
@@ -0,0 +1,170 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var configDumpJSON = `
+[
+  {
+    "section": "global",
+    "name": "mon_allow_pool_delete",
+    "value": "true",
+    "level": "advanced",
+    "can_update_at_runtime": true,
+    "mask": "",
+    "location_type": "",
+    "location_value": ""
+  },
+  {
+    "section": "osd",
+    "name": "osd_memory_target",
+    "value": "2147483648",
+    "level": "basic",
+    "can_update_at_runtime": true,
+    "mask": "host:node1",
+    "location_type": "host",
+    "location_value": "node1"
+  }
+]
+`
+
+var configShowJSON = `
+[
+  {
+    "name": "admin_socket",
+    "value": "/var/run/ceph/ceph-osd.0.asok",
+    "source": "default"
+  },
+  {
+    "name": "osd_memory_target",
+    "value": "2147483648",
+    "source": "mon"
+  }
+]
+`
+
+func TestParseOptions(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(configDumpJSON), "", nil)
+		o, err := parseOptions(r)
+		require.NoError(t, err)
+		require.Len(t, o, 2)
+		assert.Equal(t, "mon_allow_pool_delete", o[0].Name)
+		assert.Equal(t, "advanced", o[0].Level)
+		assert.Equal(t, "osd/host:node1", o[1].Who().String())
+		assert.True(t, o[1].CanUpdateAtRuntime)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		o, err := parseOptions(r)
+		assert.Error(t, err)
+		assert.Nil(t, o)
+	})
+}
+
+func TestParseRunningOptions(t *testing.T) {
+	r := commands.NewResponse([]byte(configShowJSON), "", nil)
+	o, err := parseRunningOptions(r)
+	require.NoError(t, err)
+	require.Len(t, o, 2)
+	assert.Equal(t, RunningOption{
+		Name:   "osd_memory_target",
+		Value:  "2147483648",
+		Source: "mon",
+	}, o[1])
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	o, err = parseRunningOptions(r)
+	assert.Error(t, err)
+	assert.Nil(t, o)
+}
+
+func TestParseValue(t *testing.T) {
+	r := commands.NewResponse([]byte(`"2147483648"`), "", nil)
+	v, err := parseValue(r)
+	require.NoError(t, err)
+	assert.Equal(t, "2147483648", v)
+
+	r = commands.NewResponse([]byte(`{}`), "", nil)
+	_, err = parseValue(r)
+	assert.Error(t, err)
+}
+
+func TestSetGetRemove(t *testing.T) {
+	ca := getAdmin(t)
+	who := Who{Section: SectionClient}
+	name := "rbd_cache_size"
+
+	err := ca.Set(who, name, "67108864")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, ca.Remove(who, name))
+	})
+
+	v, err := ca.Get(Who{Section: "client.go-ceph"}, name)
+	require.NoError(t, err)
+	assert.Equal(t, "67108864", v)
+
+	o, err := ca.Dump()
+	require.NoError(t, err)
+	found := false
+	for _, opt := range o {
+		if opt.Name == name {
+			found = true
+			assert.Equal(t, who, opt.Who())
+			assert.Equal(t, "67108864", opt.Value)
+		}
+	}
+	assert.True(t, found)
+
+	err = ca.Set(who, "go_ceph_no_such_option", "1")
+	assert.Error(t, err)
+}
+
+func TestShow(t *testing.T) {
+	ca := getAdmin(t)
+	o, err := ca.Show("mon.a")
+	require.NoError(t, err)
+	assert.NotEmpty(t, o)
+}
+
+func TestAssimilateConf(t *testing.T) {
+	ca := NewFromInputBufferConn(radosConnector.GetConn(t))
+	conf := "[client]\n\trbd_cache_max_dirty = 25165824\n\tgo_ceph_no_such_option = 1\n"
+	rest, err := ca.AssimilateConf(conf)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, ca.Remove(Who{Section: SectionClient}, "rbd_cache_max_dirty"))
+	})
+	assert.Contains(t, rest, "go_ceph_no_such_option")
+
+	v, err := ca.Get(Who{Section: "client.go-ceph"}, "rbd_cache_max_dirty")
+	require.NoError(t, err)
+	assert.Equal(t, "25165824", v)
+}
+
+type noInputBufferCommander struct{}
+
+func (noInputBufferCommander) MonCommand([]byte) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+func (noInputBufferCommander) MgrCommand([][]byte) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+func TestAssimilateConfNoInputBuffer(t *testing.T) {
+	ca := NewFromConn(noInputBufferCommander{})
+	_, err := ca.AssimilateConf("[global]\n")
+	assert.Equal(t, errNoInputBuffer, err)
+}
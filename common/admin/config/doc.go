@@ -0,0 +1,6 @@
+/*
+Package config from common/admin contains a set of APIs used to manage the
+options stored in the centralized configuration database of a Ceph cluster,
+as well as the entries of its config-key store.
+*/
+package config
@@ -0,0 +1,124 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const obtainedKeyPrefix = "obtained"
+
+// GetKey returns the value of the given key of the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key get <key>
+func (ca *Admin) GetKey(key string) (string, error) {
+	m := map[string]string{
+		"prefix": "config-key get",
+		"key":    key,
+	}
+	res := commands.MarshalMonCommand(ca.conn, m).FilterPrefix(obtainedKeyPrefix)
+	if err := res.NoStatus().End(); err != nil {
+		return "", err
+	}
+	return string(res.Body()), nil
+}
+
+// SetKey sets the given key of the config-key store to the given value.
+//
+// Similar To:
+//
+//	ceph config-key set <key> <value>
+func (ca *Admin) SetKey(key, value string) error {
+	m := map[string]string{
+		"prefix": "config-key set",
+		"key":    key,
+		"val":    value,
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+}
+
+// RemoveKey removes the given key from the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key rm <key>
+func (ca *Admin) RemoveKey(key string) error {
+	m := map[string]string{
+		"prefix": "config-key rm",
+		"key":    key,
+	}
+	return commands.MarshalMonCommand(ca.conn, m).NoBody().End()
+}
+
+func parseKeys(res commands.Response) ([]string, error) {
+	keys := []string{}
+	if err := res.NoStatus().Unmarshal(&keys).End(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// ListKeys returns the keys of the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key ls
+func (ca *Admin) ListKeys() ([]string, error) {
+	m := map[string]string{
+		"prefix": "config-key ls",
+		"format": "json",
+	}
+	return parseKeys(commands.MarshalMonCommand(ca.conn, m))
+}
+
+func parseKeyExists(res commands.Response) (bool, error) {
+	if res.NotFound() {
+		return false, nil
+	}
+	if err := res.NoBody().End(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// KeyExists returns true if the given key exists in the config-key store.
+//
+// Similar To:
+//
+//	ceph config-key exists <key>
+func (ca *Admin) KeyExists(key string) (bool, error) {
+	m := map[string]string{
+		"prefix": "config-key exists",
+		"key":    key,
+	}
+	return parseKeyExists(commands.MarshalMonCommand(ca.conn, m))
+}
+
+func parseKeyDump(res commands.Response) (map[string]string, error) {
+	d := map[string]string{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// DumpKeys returns the keys of the config-key store that start with the
+// given prefix along with their values. All keys are returned if the prefix
+// is empty.
+//
+// Similar To:
+//
+//	ceph config-key dump [<prefix>]
+func (ca *Admin) DumpKeys(prefix string) (map[string]string, error) {
+	m := map[string]string{
+		"prefix": "config-key dump",
+		"format": "json",
+	}
+	if prefix != "" {
+		m["key"] = prefix
+	}
+	return parseKeyDump(commands.MarshalMonCommand(ca.conn, m))
+}
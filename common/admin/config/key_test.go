@@ -0,0 +1,93 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+type testCephError int
+
+func (e testCephError) Error() string {
+	return "ceph error"
+}
+
+func (e testCephError) ErrorCode() int {
+	return int(e)
+}
+
+func TestParseKeyExists(t *testing.T) {
+	r := commands.NewResponse(nil, "key 'foo' exists", nil)
+	ok, err := parseKeyExists(r)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	r = commands.NewResponse(nil, "key 'foo' doesn't exist", testCephError(-2))
+	ok, err = parseKeyExists(r)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	r = commands.NewResponse(nil, "", testCephError(-13))
+	ok, err = parseKeyExists(r)
+	assert.Error(t, err)
+	assert.False(t, ok)
+}
+
+func TestParseKeys(t *testing.T) {
+	r := commands.NewResponse([]byte(`["config-history/1/","mgr/dashboard/ssl"]`), "", nil)
+	keys, err := parseKeys(r)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"config-history/1/", "mgr/dashboard/ssl"}, keys)
+
+	r = commands.NewResponse([]byte(`{"mgr/dashboard/ssl":"false"}`), "", nil)
+	d, err := parseKeyDump(r)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"mgr/dashboard/ssl": "false"}, d)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	_, err = parseKeys(r)
+	assert.Error(t, err)
+	_, err = parseKeyDump(r)
+	assert.Error(t, err)
+}
+
+func TestKeys(t *testing.T) {
+	ca := getAdmin(t)
+	key := "go-ceph/test/key1"
+
+	ok, err := ca.KeyExists(key)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	err = ca.SetKey(key, "hello world")
+	require.NoError(t, err)
+
+	ok, err = ca.KeyExists(key)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	v, err := ca.GetKey(key)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", v)
+
+	keys, err := ca.ListKeys()
+	require.NoError(t, err)
+	assert.Contains(t, keys, key)
+
+	d, err := ca.DumpKeys("go-ceph/test/")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{key: "hello world"}, d)
+
+	err = ca.RemoveKey(key)
+	require.NoError(t, err)
+
+	_, err = ca.GetKey(key)
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"strings"
+)
+
+// Sections of the configuration database that options are set for.
+const (
+	// SectionGlobal contains the options of all daemons and clients.
+	SectionGlobal = "global"
+	// SectionMon contains the options of the monitors.
+	SectionMon = "mon"
+	// SectionMgr contains the options of the managers.
+	SectionMgr = "mgr"
+	// SectionOSD contains the options of the OSDs.
+	SectionOSD = "osd"
+	// SectionMDS contains the options of the metadata servers.
+	SectionMDS = "mds"
+	// SectionClient contains the options of the clients.
+	SectionClient = "client"
+)
+
+const (
+	// LocationHost is the location type that limits options to the daemons
+	// of a host.
+	LocationHost = "host"
+
+	classMaskPrefix = "class:"
+)
+
+// Who selects the daemons or clients that an option applies to. It consists
+// of a section, like "osd" or "osd.1", optionally limited by the CRUSH
+// location or the device class of the daemons.
+type Who struct {
+	// Section is the name of a section, like SectionGlobal, a daemon type or
+	// the name of a daemon or client.
+	Section string
+	// LocationType and LocationValue limit the option to the daemons at the
+	// given CRUSH location, like the LocationHost "node1".
+	LocationType  string
+	LocationValue string
+	// DeviceClass limits the option to the OSDs of the device class.
+	DeviceClass string
+}
+
+// String returns the masked section in the format expected by ceph, like
+// "osd/host:node1/class:ssd".
+func (w Who) String() string {
+	s := []string{w.Section}
+	if w.LocationType != "" {
+		s = append(s, w.LocationType+":"+w.LocationValue)
+	}
+	if w.DeviceClass != "" {
+		s = append(s, classMaskPrefix+w.DeviceClass)
+	}
+	return strings.Join(s, "/")
+}
@@ -0,0 +1,51 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWhoString(t *testing.T) {
+	assert.Equal(t, "global", Who{Section: SectionGlobal}.String())
+	assert.Equal(t, "osd.3", Who{Section: "osd.3"}.String())
+	assert.Equal(t, "osd/host:node1", Who{
+		Section:       SectionOSD,
+		LocationType:  LocationHost,
+		LocationValue: "node1",
+	}.String())
+	assert.Equal(t, "osd/class:ssd", Who{
+		Section:     SectionOSD,
+		DeviceClass: "ssd",
+	}.String())
+	assert.Equal(t, "osd/rack:r1/class:hdd", Who{
+		Section:       SectionOSD,
+		LocationType:  "rack",
+		LocationValue: "r1",
+		DeviceClass:   "hdd",
+	}.String())
+}
+
+func TestOptionWho(t *testing.T) {
+	o := Option{
+		Section:       "osd",
+		Name:          "osd_memory_target",
+		Mask:          "host:node1/class:ssd",
+		LocationType:  "host",
+		LocationValue: "node1",
+	}
+	w := o.Who()
+	assert.Equal(t, Who{
+		Section:       SectionOSD,
+		LocationType:  LocationHost,
+		LocationValue: "node1",
+		DeviceClass:   "ssd",
+	}, w)
+	assert.Equal(t, "osd/host:node1/class:ssd", w.String())
+
+	o = Option{Section: "global", Name: "mon_allow_pool_delete"}
+	assert.Equal(t, Who{Section: SectionGlobal}, o.Who())
+}
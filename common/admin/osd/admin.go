@@ -0,0 +1,32 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"strconv"
+
+	ccom "github.com/ceph/go-ceph/common/commands"
+)
+
+// Admin is used to administer the OSDs of a ceph cluster.
+type Admin struct {
+	conn ccom.RadosCommander
+}
+
+// NewFromConn creates an new management object from a preexisting
+// rados connection. The existing connection can be rados.Conn or any
+// type implementing the RadosCommander interface.
+func NewFromConn(conn ccom.RadosCommander) *Admin {
+	return &Admin{conn}
+}
+
+// osdIDs converts the OSD IDs to the list of strings expected by the
+// commands that accept multiple OSDs.
+func osdIDs(ids []int64) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return s
+}
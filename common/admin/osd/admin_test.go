@@ -0,0 +1,23 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func getAdmin(t *testing.T) *Admin {
+	return NewFromConn(radosConnector.Get(t))
+}
+
+func TestOSDIDs(t *testing.T) {
+	assert.Equal(t, []string{}, osdIDs(nil))
+	assert.Equal(t, []string{"0", "12"}, osdIDs([]int64{0, 12}))
+}
@@ -0,0 +1,104 @@
+//go:build !(nautilus || octopus) && ceph_preview
+// +build !nautilus,!octopus,ceph_preview
+
+package osd
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const blocklistListedPrefix = "listed"
+
+// ceph formats the expiry times of blocklist entries differently depending
+// on its version
+var blocklistTimeLayouts = []string{
+	"2006-01-02T15:04:05.999999-0700",
+	"2006-01-02 15:04:05.999999",
+}
+
+// BlocklistEntry is a client address blocklisted until the expiry time.
+type BlocklistEntry struct {
+	Addr  string
+	Until time.Time
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (e *BlocklistEntry) UnmarshalJSON(b []byte) error {
+	raw := struct {
+		Addr  string `json:"addr"`
+		Until string `json:"until"`
+	}{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for _, layout := range blocklistTimeLayouts {
+		if t, err := time.Parse(layout, raw.Until); err == nil {
+			*e = BlocklistEntry{Addr: raw.Addr, Until: t}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid blocklist expiry time %q", raw.Until)
+}
+
+// BlocklistAdd blocklists the client with the given address, like
+// "10.0.0.1:0/3710147553". The entry expires after the given duration. If
+// the duration is zero the cluster's default expiry is used.
+//
+// Similar To:
+//
+//	ceph osd blocklist add <addr> [<expire>]
+func (oa *Admin) BlocklistAdd(addr string, expire time.Duration) error {
+	m := map[string]interface{}{
+		"prefix":      "osd blocklist",
+		"blocklistop": "add",
+		"addr":        addr,
+		"format":      "json",
+	}
+	if expire > 0 {
+		m["expire"] = expire.Seconds()
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+// BlocklistRemove removes the client with the given address from the
+// blocklist.
+//
+// Similar To:
+//
+//	ceph osd blocklist rm <addr>
+func (oa *Admin) BlocklistRemove(addr string) error {
+	m := map[string]string{
+		"prefix":      "osd blocklist",
+		"blocklistop": "rm",
+		"addr":        addr,
+		"format":      "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+func parseBlocklist(res commands.Response) ([]BlocklistEntry, error) {
+	l := []BlocklistEntry{}
+	res = res.FilterPrefix(blocklistListedPrefix)
+	if err := res.NoStatus().Unmarshal(&l).End(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Blocklist returns the blocklisted client addresses along with their
+// expiry times.
+//
+// Similar To:
+//
+//	ceph osd blocklist ls
+func (oa *Admin) Blocklist() ([]BlocklistEntry, error) {
+	m := map[string]string{
+		"prefix": "osd blocklist ls",
+		"format": "json",
+	}
+	return parseBlocklist(commands.MarshalMonCommand(oa.conn, m))
+}
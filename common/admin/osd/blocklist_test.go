@@ -0,0 +1,87 @@
+//go:build !(nautilus || octopus) && ceph_preview
+// +build !nautilus,!octopus,ceph_preview
+
+package osd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var blocklistJSON = `
+[
+  {
+    "addr": "10.0.0.7:0/3710147553",
+    "until": "2023-08-02T11:04:17.386367+0000"
+  },
+  {
+    "addr": "10.0.0.8:0/1022004588",
+    "until": "2023-08-02 12:00:00.000000"
+  }
+]
+`
+
+func TestParseBlocklist(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(blocklistJSON), "listed 2 entries", nil)
+		l, err := parseBlocklist(r)
+		require.NoError(t, err)
+		require.Len(t, l, 2)
+		assert.Equal(t, "10.0.0.7:0/3710147553", l[0].Addr)
+		assert.True(t, l[0].Until.Equal(
+			time.Date(2023, 8, 2, 11, 4, 17, 386367000, time.UTC)))
+		assert.True(t, l[1].Until.Equal(
+			time.Date(2023, 8, 2, 12, 0, 0, 0, time.UTC)))
+	})
+	t.Run("empty", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[]`), "listed 0 entries", nil)
+		l, err := parseBlocklist(r)
+		require.NoError(t, err)
+		assert.Len(t, l, 0)
+	})
+	t.Run("invalidTime", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`[{"addr":"10.0.0.7:0/1","until":"soon"}]`), "", nil)
+		_, err := parseBlocklist(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseBlocklist(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestBlocklist(t *testing.T) {
+	oa := getAdmin(t)
+	addr := "192.0.2.17:0/3710147553"
+
+	err := oa.BlocklistAdd(addr, time.Hour)
+	require.NoError(t, err)
+
+	l, err := oa.Blocklist()
+	require.NoError(t, err)
+	var entry *BlocklistEntry
+	for i := range l {
+		if l[i].Addr == addr {
+			entry = &l[i]
+		}
+	}
+	require.NotNil(t, entry)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), entry.Until, 5*time.Minute)
+
+	err = oa.BlocklistRemove(addr)
+	require.NoError(t, err)
+
+	l, err = oa.Blocklist()
+	require.NoError(t, err)
+	for _, e := range l {
+		assert.NotEqual(t, addr, e.Addr)
+	}
+}
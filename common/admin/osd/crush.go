@@ -0,0 +1,96 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"fmt"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func parseCrushRuleNames(res commands.Response) ([]string, error) {
+	var names []string
+	if err := res.NoStatus().Unmarshal(&names).End(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// ListCrushRules returns the names of the CRUSH rules.
+//
+// Similar To:
+//
+//	ceph osd crush rule ls
+func (oa *Admin) ListCrushRules() ([]string, error) {
+	m := map[string]string{
+		"prefix": "osd crush rule ls",
+		"format": "json",
+	}
+	return parseCrushRuleNames(commands.MarshalMonCommand(oa.conn, m))
+}
+
+// ReplicatedRule describes a CRUSH rule for replicated pools.
+type ReplicatedRule struct {
+	// Name of the rule.
+	Name string
+	// Root is the name of the CRUSH bucket the placement starts at.
+	Root string
+	// FailureDomain is the type of the CRUSH buckets the replicas are
+	// spread across, for example "host".
+	FailureDomain string
+	// DeviceClass optionally limits the placement to the OSDs of the
+	// device class, for example "ssd".
+	DeviceClass string
+}
+
+// CreateReplicatedCrushRule creates a CRUSH rule for replicated pools. It
+// is not an error if a rule with the same name already exists.
+//
+// Similar To:
+//
+//	ceph osd crush rule create-replicated <name> <root> <type> [<class>]
+func (oa *Admin) CreateReplicatedCrushRule(rule ReplicatedRule) error {
+	m := map[string]string{
+		"prefix": "osd crush rule create-replicated",
+		"name":   rule.Name,
+		"root":   rule.Root,
+		"type":   rule.FailureDomain,
+		"format": "json",
+	}
+	if rule.DeviceClass != "" {
+		m["class"] = rule.DeviceClass
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+// RemoveCrushRule removes the CRUSH rule with the given name. The rule must
+// not be in use by any pool.
+//
+// Similar To:
+//
+//	ceph osd crush rule rm <name>
+func (oa *Admin) RemoveCrushRule(name string) error {
+	m := map[string]string{
+		"prefix": "osd crush rule rm",
+		"name":   name,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+// CrushReweight sets the CRUSH weight of the OSD with the given ID. The
+// CRUSH weight is usually the capacity of the OSD in TiB.
+//
+// Similar To:
+//
+//	ceph osd crush reweight osd.<id> <weight>
+func (oa *Admin) CrushReweight(id int64, weight float64) error {
+	m := map[string]interface{}{
+		"prefix": "osd crush reweight",
+		"name":   fmt.Sprintf("osd.%d", id),
+		"weight": weight,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
@@ -0,0 +1,71 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestParseCrushRuleNames(t *testing.T) {
+	r := commands.NewResponse([]byte(`["replicated_rule","ssd_rule"]`), "", nil)
+	names, err := parseCrushRuleNames(r)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"replicated_rule", "ssd_rule"}, names)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	names, err = parseCrushRuleNames(r)
+	assert.Error(t, err)
+	assert.Nil(t, names)
+}
+
+func TestCrushRules(t *testing.T) {
+	oa := getAdmin(t)
+
+	names, err := oa.ListCrushRules()
+	require.NoError(t, err)
+	assert.Contains(t, names, "replicated_rule")
+
+	rule := ReplicatedRule{
+		Name:          "go_ceph_test_rule",
+		Root:          "default",
+		FailureDomain: "osd",
+	}
+	err = oa.CreateReplicatedCrushRule(rule)
+	require.NoError(t, err)
+	names, err = oa.ListCrushRules()
+	require.NoError(t, err)
+	assert.Contains(t, names, rule.Name)
+
+	err = oa.RemoveCrushRule(rule.Name)
+	require.NoError(t, err)
+	names, err = oa.ListCrushRules()
+	require.NoError(t, err)
+	assert.NotContains(t, names, rule.Name)
+
+	err = oa.CreateReplicatedCrushRule(ReplicatedRule{
+		Name:          "go_ceph_bad_rule",
+		Root:          "no_such_root",
+		FailureDomain: "osd",
+	})
+	assert.Error(t, err)
+}
+
+func TestCrushReweight(t *testing.T) {
+	oa := getAdmin(t)
+	tree, err := oa.Tree()
+	require.NoError(t, err)
+	osd0 := tree.Node(0)
+	require.NotNil(t, osd0)
+
+	err = oa.CrushReweight(0, osd0.CrushWeight)
+	assert.NoError(t, err)
+	err = oa.CrushReweight(999, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DfNode reports the utilization of an OSD, or of a CRUSH bucket when the
+// tree format is requested.
+type DfNode struct {
+	ID          int64   `json:"id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	TypeID      int     `json:"type_id"`
+	DeviceClass string  `json:"device_class"`
+	CrushWeight float64 `json:"crush_weight"`
+	Depth       int     `json:"depth"`
+	Reweight    float64 `json:"reweight"`
+	KB          uint64  `json:"kb"`
+	KBUsed      uint64  `json:"kb_used"`
+	KBUsedData  uint64  `json:"kb_used_data"`
+	KBUsedOmap  uint64  `json:"kb_used_omap"`
+	KBUsedMeta  uint64  `json:"kb_used_meta"`
+	KBAvail     uint64  `json:"kb_avail"`
+	Utilization float64 `json:"utilization"`
+	Var         float64 `json:"var"`
+	PGs         int     `json:"pgs"`
+	Status      string  `json:"status"`
+}
+
+// DfSummary reports the total utilization of the OSDs.
+type DfSummary struct {
+	TotalKB            uint64  `json:"total_kb"`
+	TotalKBUsed        uint64  `json:"total_kb_used"`
+	TotalKBUsedData    uint64  `json:"total_kb_used_data"`
+	TotalKBUsedOmap    uint64  `json:"total_kb_used_omap"`
+	TotalKBUsedMeta    uint64  `json:"total_kb_used_meta"`
+	TotalKBAvail       uint64  `json:"total_kb_avail"`
+	AverageUtilization float64 `json:"average_utilization"`
+	MinVar             float64 `json:"min_var"`
+	MaxVar             float64 `json:"max_var"`
+	Dev                float64 `json:"dev"`
+}
+
+// Df reports the utilization of the OSDs.
+type Df struct {
+	Nodes   []DfNode  `json:"nodes"`
+	Stray   []DfNode  `json:"stray"`
+	Summary DfSummary `json:"summary"`
+}
+
+func parseDf(res commands.Response) (*Df, error) {
+	df := &Df{}
+	if err := res.NoStatus().Unmarshal(df).End(); err != nil {
+		return nil, err
+	}
+	return df, nil
+}
+
+// Df returns the utilization of the OSDs.
+//
+// Similar To:
+//
+//	ceph osd df
+func (oa *Admin) Df() (*Df, error) {
+	m := map[string]string{
+		"prefix": "osd df",
+		"format": "json",
+	}
+	return parseDf(commands.MarshalMonCommand(oa.conn, m))
+}
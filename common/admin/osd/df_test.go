@@ -0,0 +1,86 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var osdDfJSON = `
+{
+  "nodes": [
+    {
+      "id": 0,
+      "device_class": "hdd",
+      "name": "osd.0",
+      "type": "osd",
+      "type_id": 0,
+      "crush_weight": 0.0194854736328125,
+      "depth": 0,
+      "pool_weights": {},
+      "reweight": 1,
+      "kb": 20971520,
+      "kb_used": 1073744,
+      "kb_used_data": 1232,
+      "kb_used_omap": 1,
+      "kb_used_meta": 1072510,
+      "kb_avail": 19897776,
+      "utilization": 5.1200866699218746,
+      "var": 1,
+      "pgs": 33,
+      "status": "up"
+    }
+  ],
+  "stray": [],
+  "summary": {
+    "total_kb": 20971520,
+    "total_kb_used": 1073744,
+    "total_kb_used_data": 1232,
+    "total_kb_used_omap": 1,
+    "total_kb_used_meta": 1072510,
+    "total_kb_avail": 19897776,
+    "average_utilization": 5.1200866699218746,
+    "min_var": 1,
+    "max_var": 1,
+    "dev": 0
+  }
+}
+`
+
+func TestParseDf(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(osdDfJSON), "", nil)
+		df, err := parseDf(r)
+		require.NoError(t, err)
+		require.Len(t, df.Nodes, 1)
+		assert.Len(t, df.Stray, 0)
+		assert.Equal(t, "osd.0", df.Nodes[0].Name)
+		assert.EqualValues(t, 20971520, df.Nodes[0].KB)
+		assert.EqualValues(t, 19897776, df.Nodes[0].KBAvail)
+		assert.Equal(t, 33, df.Nodes[0].PGs)
+		assert.InDelta(t, 5.12, df.Nodes[0].Utilization, 0.01)
+		assert.EqualValues(t, 1073744, df.Summary.TotalKBUsed)
+		assert.InDelta(t, 5.12, df.Summary.AverageUtilization, 0.01)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		df, err := parseDf(r)
+		assert.Error(t, err)
+		assert.Nil(t, df)
+	})
+}
+
+func TestDf(t *testing.T) {
+	oa := getAdmin(t)
+	df, err := oa.Df()
+	require.NoError(t, err)
+	require.NotEmpty(t, df.Nodes)
+	assert.Greater(t, df.Summary.TotalKB, uint64(0))
+}
@@ -0,0 +1,5 @@
+/*
+Package osd from common/admin contains a set of APIs used to interact with
+and administer the Ceph object storage daemons (OSDs) and the CRUSH map.
+*/
+package osd
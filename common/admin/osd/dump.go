@@ -0,0 +1,87 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"strings"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// DumpPool contains the pool information reported by the OSD map.
+type DumpPool struct {
+	Pool                int64                        `json:"pool"`
+	PoolName            string                       `json:"pool_name"`
+	Type                int                          `json:"type"`
+	Size                int                          `json:"size"`
+	MinSize             int                          `json:"min_size"`
+	CrushRule           int64                        `json:"crush_rule"`
+	PGNum               int                          `json:"pg_num"`
+	PGPlacementNum      int                          `json:"pg_placement_num"`
+	ErasureCodeProfile  string                       `json:"erasure_code_profile"`
+	ApplicationMetadata map[string]map[string]string `json:"application_metadata"`
+}
+
+// DumpOSD contains the OSD information reported by the OSD map.
+type DumpOSD struct {
+	OSD             int64    `json:"osd"`
+	UUID            string   `json:"uuid"`
+	Up              int      `json:"up"`
+	In              int      `json:"in"`
+	Weight          float64  `json:"weight"`
+	PrimaryAffinity float64  `json:"primary_affinity"`
+	PublicAddr      string   `json:"public_addr"`
+	ClusterAddr     string   `json:"cluster_addr"`
+	State           []string `json:"state"`
+}
+
+// Dump is the OSD map of the cluster.
+type Dump struct {
+	Epoch                  uint64     `json:"epoch"`
+	FSID                   string     `json:"fsid"`
+	Created                string     `json:"created"`
+	Modified               string     `json:"modified"`
+	Flags                  string     `json:"flags"`
+	CrushVersion           uint64     `json:"crush_version"`
+	FullRatio              float64    `json:"full_ratio"`
+	BackfillfullRatio      float64    `json:"backfillfull_ratio"`
+	NearfullRatio          float64    `json:"nearfull_ratio"`
+	MaxOSD                 int64      `json:"max_osd"`
+	RequireMinCompatClient string     `json:"require_min_compat_client"`
+	MinCompatClient        string     `json:"min_compat_client"`
+	RequireOSDRelease      string     `json:"require_osd_release"`
+	Pools                  []DumpPool `json:"pools"`
+	OSDs                   []DumpOSD  `json:"osds"`
+}
+
+// HasFlag returns true if the given cluster flag is set in the OSD map.
+func (d *Dump) HasFlag(flag Flag) bool {
+	for _, f := range strings.Split(d.Flags, ",") {
+		if f == string(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDump(res commands.Response) (*Dump, error) {
+	d := &Dump{}
+	if err := res.NoStatus().Unmarshal(d).End(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Dump returns the OSD map of the cluster.
+//
+// Similar To:
+//
+//	ceph osd dump
+func (oa *Admin) Dump() (*Dump, error) {
+	m := map[string]string{
+		"prefix": "osd dump",
+		"format": "json",
+	}
+	return parseDump(commands.MarshalMonCommand(oa.conn, m))
+}
@@ -0,0 +1,126 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var osdDumpJSON = `
+{
+  "epoch": 21,
+  "fsid": "2bd26aa1-1cd4-4bc6-9a5f-6a5f5d1b8d4b",
+  "created": "2023-08-01T10:03:10.135046+0000",
+  "modified": "2023-08-01T10:05:43.447851+0000",
+  "last_up_change": "2023-08-01T10:03:16.474021+0000",
+  "last_in_change": "2023-08-01T10:03:13.148622+0000",
+  "flags": "noout,sortbitwise,recovery_deletes,purged_snapdirs,pglog_hardlimit",
+  "flags_num": 5799936,
+  "flags_set": ["noout", "pglog_hardlimit", "purged_snapdirs", "recovery_deletes", "sortbitwise"],
+  "crush_version": 3,
+  "full_ratio": 0.95,
+  "backfillfull_ratio": 0.9,
+  "nearfull_ratio": 0.85,
+  "cluster_snapshot": "",
+  "pool_max": 2,
+  "max_osd": 1,
+  "require_min_compat_client": "luminous",
+  "min_compat_client": "jewel",
+  "require_osd_release": "quincy",
+  "pools": [
+    {
+      "pool": 1,
+      "pool_name": ".mgr",
+      "create_time": "2023-08-01T10:03:18.162104+0000",
+      "flags": 1,
+      "flags_names": "hashpspool",
+      "type": 1,
+      "size": 1,
+      "min_size": 1,
+      "crush_rule": 0,
+      "pg_num": 1,
+      "pg_placement_num": 1,
+      "erasure_code_profile": "",
+      "application_metadata": {
+        "mgr": {}
+      }
+    },
+    {
+      "pool": 2,
+      "pool_name": "rbd",
+      "type": 1,
+      "size": 1,
+      "min_size": 1,
+      "crush_rule": 0,
+      "pg_num": 32,
+      "pg_placement_num": 32,
+      "erasure_code_profile": "",
+      "application_metadata": {
+        "rbd": {}
+      }
+    }
+  ],
+  "osds": [
+    {
+      "osd": 0,
+      "uuid": "a0c0a0b8-2c8e-4bd0-9a0b-2a8d5b7e6d1f",
+      "up": 1,
+      "in": 1,
+      "weight": 1,
+      "primary_affinity": 1,
+      "last_clean_begin": 0,
+      "last_clean_end": 0,
+      "up_from": 5,
+      "up_thru": 19,
+      "down_at": 0,
+      "lost_at": 0,
+      "public_addr": "10.0.0.2:6801/2158912871",
+      "cluster_addr": "10.0.0.2:6802/2158912871",
+      "state": ["exists", "up"]
+    }
+  ]
+}
+`
+
+func TestParseDump(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(osdDumpJSON), "", nil)
+		d, err := parseDump(r)
+		require.NoError(t, err)
+		assert.EqualValues(t, 21, d.Epoch)
+		assert.Equal(t, "2bd26aa1-1cd4-4bc6-9a5f-6a5f5d1b8d4b", d.FSID)
+		assert.True(t, d.HasFlag(FlagNoOut))
+		assert.False(t, d.HasFlag(FlagNoRebalance))
+		assert.Equal(t, "quincy", d.RequireOSDRelease)
+		assert.InDelta(t, 0.85, d.NearfullRatio, 0.001)
+		require.Len(t, d.Pools, 2)
+		assert.Equal(t, "rbd", d.Pools[1].PoolName)
+		assert.Equal(t, 32, d.Pools[1].PGNum)
+		assert.Contains(t, d.Pools[1].ApplicationMetadata, "rbd")
+		require.Len(t, d.OSDs, 1)
+		assert.Equal(t, 1, d.OSDs[0].Up)
+		assert.Equal(t, []string{"exists", "up"}, d.OSDs[0].State)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		d, err := parseDump(r)
+		assert.Error(t, err)
+		assert.Nil(t, d)
+	})
+}
+
+func TestDump(t *testing.T) {
+	oa := getAdmin(t)
+	d, err := oa.Dump()
+	require.NoError(t, err)
+	assert.Greater(t, d.Epoch, uint64(0))
+	assert.NotEmpty(t, d.FSID)
+	assert.NotEmpty(t, d.OSDs)
+}
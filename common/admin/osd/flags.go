@@ -0,0 +1,62 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Flag is a cluster wide flag that changes the behavior of the OSDs.
+type Flag string
+
+const (
+	// FlagNoOut prevents OSDs from being marked out automatically.
+	FlagNoOut = Flag("noout")
+	// FlagNoIn prevents OSDs from being marked in automatically.
+	FlagNoIn = Flag("noin")
+	// FlagNoUp prevents OSDs from being marked up.
+	FlagNoUp = Flag("noup")
+	// FlagNoDown prevents OSDs from being marked down.
+	FlagNoDown = Flag("nodown")
+	// FlagNoRebalance prevents the rebalancing of data.
+	FlagNoRebalance = Flag("norebalance")
+	// FlagNoRecover prevents the recovery of placement groups.
+	FlagNoRecover = Flag("norecover")
+	// FlagNoBackfill prevents the backfill of placement groups.
+	FlagNoBackfill = Flag("nobackfill")
+	// FlagNoScrub prevents the scrubbing of placement groups.
+	FlagNoScrub = Flag("noscrub")
+	// FlagNoDeepScrub prevents the deep scrubbing of placement groups.
+	FlagNoDeepScrub = Flag("nodeep-scrub")
+	// FlagPause stops all reads and writes of the clients.
+	FlagPause = Flag("pause")
+)
+
+// SetFlag sets the given cluster flag.
+//
+// Similar To:
+//
+//	ceph osd set <flag>
+func (oa *Admin) SetFlag(flag Flag) error {
+	m := map[string]string{
+		"prefix": "osd set",
+		"key":    string(flag),
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+// UnsetFlag unsets the given cluster flag.
+//
+// Similar To:
+//
+//	ceph osd unset <flag>
+func (oa *Admin) UnsetFlag(flag Flag) error {
+	m := map[string]string{
+		"prefix": "osd unset",
+		"key":    string(flag),
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
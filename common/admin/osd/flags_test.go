@@ -0,0 +1,32 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetUnsetFlag(t *testing.T) {
+	oa := getAdmin(t)
+
+	for _, flag := range []Flag{FlagNoOut, FlagNoRebalance} {
+		err := oa.SetFlag(flag)
+		require.NoError(t, err)
+		d, err := oa.Dump()
+		require.NoError(t, err)
+		assert.True(t, d.HasFlag(flag))
+
+		err = oa.UnsetFlag(flag)
+		require.NoError(t, err)
+		d, err = oa.Dump()
+		require.NoError(t, err)
+		assert.False(t, d.HasFlag(flag))
+	}
+
+	err := oa.SetFlag(Flag("notaflag"))
+	assert.Error(t, err)
+}
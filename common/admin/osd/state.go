@@ -0,0 +1,61 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func (oa *Admin) markOSDs(prefix string, ids []int64) error {
+	m := map[string]interface{}{
+		"prefix": prefix,
+		"ids":    osdIDs(ids),
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
+
+// Out marks the given OSDs out of the cluster, causing their data to be
+// migrated to the other OSDs.
+//
+// Similar To:
+//
+//	ceph osd out <id> [<id>...]
+func (oa *Admin) Out(ids ...int64) error {
+	return oa.markOSDs("osd out", ids)
+}
+
+// In marks the given OSDs in the cluster.
+//
+// Similar To:
+//
+//	ceph osd in <id> [<id>...]
+func (oa *Admin) In(ids ...int64) error {
+	return oa.markOSDs("osd in", ids)
+}
+
+// Down marks the given OSDs down. A running OSD marks itself up again.
+//
+// Similar To:
+//
+//	ceph osd down <id> [<id>...]
+func (oa *Admin) Down(ids ...int64) error {
+	return oa.markOSDs("osd down", ids)
+}
+
+// Reweight sets the override weight of the OSD with the given ID. The
+// weight is a value between 0 and 1.
+//
+// Similar To:
+//
+//	ceph osd reweight <id> <weight>
+func (oa *Admin) Reweight(id int64, weight float64) error {
+	m := map[string]interface{}{
+		"prefix": "osd reweight",
+		"id":     id,
+		"weight": weight,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(oa.conn, m).NoBody().End()
+}
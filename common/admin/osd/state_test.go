@@ -0,0 +1,50 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getOSD(t *testing.T, oa *Admin, id int64) DumpOSD {
+	d, err := oa.Dump()
+	require.NoError(t, err)
+	for _, o := range d.OSDs {
+		if o.OSD == id {
+			return o
+		}
+	}
+	t.Fatalf("osd.%d not found", id)
+	return DumpOSD{}
+}
+
+func TestOutIn(t *testing.T) {
+	oa := getAdmin(t)
+
+	err := oa.Out(0)
+	require.NoError(t, err)
+	assert.Equal(t, 0, getOSD(t, oa, 0).In)
+
+	err = oa.In(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, getOSD(t, oa, 0).In)
+}
+
+func TestReweight(t *testing.T) {
+	oa := getAdmin(t)
+
+	err := oa.Reweight(0, 0.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, getOSD(t, oa, 0).Weight, 0.01)
+
+	err = oa.Reweight(0, 1)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, getOSD(t, oa, 0).Weight, 0.01)
+
+	err = oa.Reweight(0, 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// TreeNode is a node of the OSD tree. It is either a CRUSH bucket, like a
+// host or the root, or an OSD.
+type TreeNode struct {
+	ID       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	TypeID   int     `json:"type_id"`
+	Children []int64 `json:"children"`
+	// The following fields are only set for OSDs.
+	DeviceClass     string  `json:"device_class"`
+	CrushWeight     float64 `json:"crush_weight"`
+	Depth           int     `json:"depth"`
+	Exists          int     `json:"exists"`
+	Status          string  `json:"status"`
+	Reweight        float64 `json:"reweight"`
+	PrimaryAffinity float64 `json:"primary_affinity"`
+}
+
+// IsOSD returns true if the node is an OSD rather than a CRUSH bucket.
+func (n TreeNode) IsOSD() bool {
+	return n.ID >= 0
+}
+
+// Tree is the hierarchy of CRUSH buckets and OSDs.
+type Tree struct {
+	// Nodes lists the buckets and the OSDs that are part of the hierarchy.
+	Nodes []TreeNode `json:"nodes"`
+	// Stray lists the OSDs that are not part of the hierarchy.
+	Stray []TreeNode `json:"stray"`
+}
+
+// Node returns the node of the tree with the given ID, or nil if the tree
+// has no such node.
+func (t *Tree) Node(id int64) *TreeNode {
+	for i := range t.Nodes {
+		if t.Nodes[i].ID == id {
+			return &t.Nodes[i]
+		}
+	}
+	for i := range t.Stray {
+		if t.Stray[i].ID == id {
+			return &t.Stray[i]
+		}
+	}
+	return nil
+}
+
+func parseTree(res commands.Response) (*Tree, error) {
+	t := &Tree{}
+	if err := res.NoStatus().Unmarshal(t).End(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Tree returns the hierarchy of CRUSH buckets and OSDs.
+//
+// Similar To:
+//
+//	ceph osd tree
+func (oa *Admin) Tree() (*Tree, error) {
+	m := map[string]string{
+		"prefix": "osd tree",
+		"format": "json",
+	}
+	return parseTree(commands.MarshalMonCommand(oa.conn, m))
+}
@@ -0,0 +1,126 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package osd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var osdTreeJSON = `
+{
+  "nodes": [
+    {
+      "id": -1,
+      "name": "default",
+      "type": "root",
+      "type_id": 11,
+      "children": [-3]
+    },
+    {
+      "id": -3,
+      "name": "node1",
+      "type": "host",
+      "type_id": 1,
+      "pool_weights": {},
+      "children": [1, 0]
+    },
+    {
+      "id": 0,
+      "device_class": "hdd",
+      "name": "osd.0",
+      "type": "osd",
+      "type_id": 0,
+      "crush_weight": 0.0194854736328125,
+      "depth": 2,
+      "pool_weights": {},
+      "exists": 1,
+      "status": "up",
+      "reweight": 1,
+      "primary_affinity": 1
+    },
+    {
+      "id": 1,
+      "device_class": "ssd",
+      "name": "osd.1",
+      "type": "osd",
+      "type_id": 0,
+      "crush_weight": 0.0194854736328125,
+      "depth": 2,
+      "pool_weights": {},
+      "exists": 1,
+      "status": "down",
+      "reweight": 0,
+      "primary_affinity": 1
+    }
+  ],
+  "stray": [
+    {
+      "id": 2,
+      "name": "osd.2",
+      "type": "osd",
+      "type_id": 0,
+      "crush_weight": 0,
+      "depth": 0,
+      "exists": 1,
+      "status": "down",
+      "reweight": 0,
+      "primary_affinity": 1
+    }
+  ]
+}
+`
+
+func TestParseTree(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(osdTreeJSON), "", nil)
+		tree, err := parseTree(r)
+		require.NoError(t, err)
+		require.Len(t, tree.Nodes, 4)
+		require.Len(t, tree.Stray, 1)
+
+		root := tree.Node(-1)
+		require.NotNil(t, root)
+		assert.False(t, root.IsOSD())
+		assert.Equal(t, "root", root.Type)
+		assert.Equal(t, []int64{-3}, root.Children)
+
+		osd1 := tree.Node(1)
+		require.NotNil(t, osd1)
+		assert.True(t, osd1.IsOSD())
+		assert.Equal(t, "osd.1", osd1.Name)
+		assert.Equal(t, "ssd", osd1.DeviceClass)
+		assert.Equal(t, "down", osd1.Status)
+		assert.InDelta(t, 0.0195, osd1.CrushWeight, 0.0001)
+
+		osd2 := tree.Node(2)
+		require.NotNil(t, osd2)
+		assert.Equal(t, "osd.2", osd2.Name)
+		assert.Nil(t, tree.Node(3))
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		tree, err := parseTree(r)
+		assert.Error(t, err)
+		assert.Nil(t, tree)
+	})
+}
+
+func TestTree(t *testing.T) {
+	oa := getAdmin(t)
+	tree, err := oa.Tree()
+	require.NoError(t, err)
+	root := tree.Node(-1)
+	require.NotNil(t, root)
+	assert.Equal(t, "root", root.Type)
+	osd0 := tree.Node(0)
+	require.NotNil(t, osd0)
+	assert.Equal(t, "osd.0", osd0.Name)
+	assert.Equal(t, "up", osd0.Status)
+}
@@ -0,0 +1,32 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	ccom "github.com/ceph/go-ceph/common/commands"
+)
+
+// Admin is used to inspect and repair the PGs of a ceph cluster.
+type Admin struct {
+	conn ccom.RadosCommander
+	// pgConn sends the commands executed by the primary OSD of a PG, it is
+	// nil if the Admin was not created by NewFromPGConn
+	pgConn ccom.PGCommander
+}
+
+// NewFromConn creates an new management object from a preexisting
+// rados connection. The existing connection can be rados.Conn or any
+// type implementing the RadosCommander interface. The Admin can not send
+// commands to PGs, see NewFromPGConn.
+func NewFromConn(conn ccom.RadosCommander) *Admin {
+	return &Admin{conn: conn}
+}
+
+// NewFromPGConn creates an new management object from a preexisting rados
+// connection that can also send commands to PGs, as needed by Query. The
+// existing connection can be rados.Conn or any type implementing the
+// RadosPGCommander interface.
+func NewFromPGConn(conn ccom.RadosPGCommander) *Admin {
+	return &Admin{conn: conn, pgConn: conn}
+}
@@ -0,0 +1,5 @@
+/*
+Package pg from common/admin contains a set of APIs used to inspect the
+placement groups (PGs) of a Ceph cluster and to scrub and repair them.
+*/
+package pg
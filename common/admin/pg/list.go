@@ -0,0 +1,204 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const (
+	dumpedPrefix = "dumped"
+	stuckOk      = "ok"
+)
+
+var errPoolAndOSD = errors.New("listing the PGs of a pool and an OSD at once is not supported")
+
+// PGBrief is the brief state of a PG, consisting of its state and the OSDs
+// it is mapped to.
+type PGBrief struct {
+	PGID          string  `json:"pgid"`
+	State         PGState `json:"state"`
+	Up            []int64 `json:"up"`
+	UpPrimary     int64   `json:"up_primary"`
+	Acting        []int64 `json:"acting"`
+	ActingPrimary int64   `json:"acting_primary"`
+}
+
+// PGStatSum contains the object and scrub error counts of a PG.
+type PGStatSum struct {
+	NumBytes              int64 `json:"num_bytes"`
+	NumObjects            int64 `json:"num_objects"`
+	NumObjectCopies       int64 `json:"num_object_copies"`
+	NumObjectsDegraded    int64 `json:"num_objects_degraded"`
+	NumObjectsMisplaced   int64 `json:"num_objects_misplaced"`
+	NumObjectsUnfound     int64 `json:"num_objects_unfound"`
+	NumScrubErrors        int64 `json:"num_scrub_errors"`
+	NumShallowScrubErrors int64 `json:"num_shallow_scrub_errors"`
+	NumDeepScrubErrors    int64 `json:"num_deep_scrub_errors"`
+}
+
+// PGStat contains the state and the statistics of a PG.
+type PGStat struct {
+	PGBrief
+	Version            string    `json:"version"`
+	LastChange         string    `json:"last_change"`
+	LastActive         string    `json:"last_active"`
+	LastClean          string    `json:"last_clean"`
+	LastScrub          string    `json:"last_scrub"`
+	LastScrubStamp     string    `json:"last_scrub_stamp"`
+	LastDeepScrub      string    `json:"last_deep_scrub"`
+	LastDeepScrubStamp string    `json:"last_deep_scrub_stamp"`
+	LogSize            int64     `json:"log_size"`
+	StatSum            PGStatSum `json:"stat_sum"`
+}
+
+// unmarshalPGs unmarshals the list of PGs of the response into v. The list
+// is either returned as is, by older versions of ceph, or wrapped in an
+// object as the given field. An empty body is an empty list.
+func unmarshalPGs(res commands.Response, field string, v interface{}) error {
+	if err := res.NoStatus().End(); err != nil {
+		return err
+	}
+	body := bytes.TrimSpace(res.Body())
+	if len(body) == 0 {
+		return nil
+	}
+	if body[0] != '[' {
+		wrapped := map[string]json.RawMessage{}
+		if err := json.Unmarshal(body, &wrapped); err != nil {
+			return err
+		}
+		if body = wrapped[field]; body == nil {
+			return nil
+		}
+	}
+	return json.Unmarshal(body, v)
+}
+
+func parseBriefs(res commands.Response) ([]PGBrief, error) {
+	l := []PGBrief{}
+	if err := unmarshalPGs(res, "pg_stats", &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DumpBrief returns the brief states of all PGs.
+//
+// Similar To:
+//
+//	ceph pg dump pgs_brief
+func (pa *Admin) DumpBrief() ([]PGBrief, error) {
+	m := map[string]interface{}{
+		"prefix":       "pg dump",
+		"dumpcontents": []string{"pgs_brief"},
+		"format":       "json",
+	}
+	return parseBriefs(commands.MarshalMgrCommand(pa.conn, m).FilterPrefix(dumpedPrefix))
+}
+
+// ListOptions limit the PGs returned by List. The PGs of a pool and the PGs
+// of an OSD can not be listed at once.
+type ListOptions struct {
+	// Pool limits the list to the PGs of the pool with the given name.
+	Pool string
+	// OSD, if not nil, limits the list to the PGs mapped to the OSD with
+	// the given ID.
+	OSD *int64
+	// States limits the list to the PGs in any of the given states, like
+	// StateInconsistent.
+	States []string
+}
+
+func parseStats(res commands.Response) ([]PGStat, error) {
+	l := []PGStat{}
+	if err := unmarshalPGs(res, "pg_stats", &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// List returns the states and statistics of the PGs selected by the given
+// options. All PGs are returned if the options are nil.
+//
+// Similar To:
+//
+//	ceph pg ls [<states>...]
+//	ceph pg ls-by-pool <pool> [<states>...]
+//	ceph pg ls-by-osd <osd> [<states>...]
+func (pa *Admin) List(o *ListOptions) ([]PGStat, error) {
+	if o == nil {
+		o = &ListOptions{}
+	}
+	m := map[string]interface{}{
+		"prefix": "pg ls",
+		"format": "json",
+	}
+	switch {
+	case o.Pool != "" && o.OSD != nil:
+		return nil, errPoolAndOSD
+	case o.Pool != "":
+		m["prefix"] = "pg ls-by-pool"
+		m["poolstr"] = o.Pool
+	case o.OSD != nil:
+		m["prefix"] = "pg ls-by-osd"
+		m["osd"] = fmt.Sprintf("osd.%d", *o.OSD)
+	}
+	if len(o.States) > 0 {
+		m["states"] = o.States
+	}
+	return parseStats(commands.MarshalMgrCommand(pa.conn, m))
+}
+
+// StuckType selects the PGs returned by DumpStuck.
+type StuckType string
+
+const (
+	// StuckInactive selects the PGs that are not active.
+	StuckInactive = StuckType("inactive")
+	// StuckUnclean selects the PGs that are not clean.
+	StuckUnclean = StuckType("unclean")
+	// StuckStale selects the PGs whose state was not reported recently.
+	StuckStale = StuckType("stale")
+	// StuckUndersized selects the PGs with fewer copies than configured.
+	StuckUndersized = StuckType("undersized")
+	// StuckDegraded selects the PGs with degraded objects.
+	StuckDegraded = StuckType("degraded")
+)
+
+func parseStuck(res commands.Response) ([]PGBrief, error) {
+	l := []PGBrief{}
+	if err := unmarshalPGs(res.FilterPrefix(stuckOk), "stuck_pg_stats", &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// DumpStuck returns the brief states of the PGs that are stuck in any of
+// the given types of states for longer than the given threshold. If no
+// types are given ceph selects the unclean PGs. If the threshold is zero
+// ceph uses its default of mon_pg_stuck_threshold.
+//
+// Similar To:
+//
+//	ceph pg dump_stuck [<types>...] [<threshold>]
+func (pa *Admin) DumpStuck(threshold time.Duration, types ...StuckType) ([]PGBrief, error) {
+	m := map[string]interface{}{
+		"prefix": "pg dump_stuck",
+		"format": "json",
+	}
+	if len(types) > 0 {
+		m["stuckops"] = types
+	}
+	if threshold > 0 {
+		m["threshold"] = int64(threshold / time.Second)
+	}
+	return parseStuck(commands.MarshalMgrCommand(pa.conn, m))
+}
@@ -0,0 +1,193 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var pgsBriefJSON = `
+[
+  {
+    "pgid": "2.7",
+    "state": "active+clean",
+    "up": [0, 1, 2],
+    "up_primary": 0,
+    "acting": [0, 1, 2],
+    "acting_primary": 0
+  },
+  {
+    "pgid": "2.6",
+    "state": "active+clean+inconsistent",
+    "up": [2, 0, 1],
+    "up_primary": 2,
+    "acting": [2, 0, 1],
+    "acting_primary": 2
+  }
+]
+`
+
+var pgLsJSON = `
+{
+  "pg_ready": true,
+  "pg_stats": [
+    {
+      "pgid": "2.6",
+      "version": "21'3",
+      "reported_seq": 52,
+      "reported_epoch": 21,
+      "state": "active+clean+inconsistent",
+      "last_fresh": "2023-08-01T10:39:52.212845+0000",
+      "last_change": "2023-08-01T10:04:23.211035+0000",
+      "last_active": "2023-08-01T10:39:52.212845+0000",
+      "last_clean": "2023-08-01T10:39:52.212845+0000",
+      "last_scrub": "21'3",
+      "last_scrub_stamp": "2023-08-01T10:39:52.212718+0000",
+      "last_deep_scrub": "21'3",
+      "last_deep_scrub_stamp": "2023-08-01T10:39:52.212718+0000",
+      "log_size": 3,
+      "ondisk_log_size": 3,
+      "stat_sum": {
+        "num_bytes": 19,
+        "num_objects": 1,
+        "num_object_clones": 0,
+        "num_object_copies": 3,
+        "num_objects_missing_on_primary": 0,
+        "num_objects_missing": 0,
+        "num_objects_degraded": 0,
+        "num_objects_misplaced": 0,
+        "num_objects_unfound": 0,
+        "num_objects_dirty": 1,
+        "num_scrub_errors": 1,
+        "num_shallow_scrub_errors": 0,
+        "num_deep_scrub_errors": 1
+      },
+      "up": [2, 0, 1],
+      "acting": [2, 0, 1],
+      "up_primary": 2,
+      "acting_primary": 2
+    }
+  ]
+}
+`
+
+func TestParseBriefs(t *testing.T) {
+	t.Run("list", func(t *testing.T) {
+		r := commands.NewResponse([]byte(pgsBriefJSON), "dumped pgs_brief", nil).
+			FilterPrefix(dumpedPrefix)
+		l, err := parseBriefs(r)
+		require.NoError(t, err)
+		require.Len(t, l, 2)
+		assert.Equal(t, "2.7", l[0].PGID)
+		assert.Equal(t, []int64{0, 1, 2}, l[0].Up)
+		assert.True(t, l[1].State.Has(StateInconsistent))
+		assert.EqualValues(t, 2, l[1].ActingPrimary)
+	})
+	t.Run("wrapped", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`{"pg_ready":true,"pg_stats":`+pgsBriefJSON+`}`), "", nil)
+		l, err := parseBriefs(r)
+		require.NoError(t, err)
+		assert.Len(t, l, 2)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		l, err := parseBriefs(r)
+		assert.Error(t, err)
+		assert.Nil(t, l)
+	})
+}
+
+func TestParseStats(t *testing.T) {
+	r := commands.NewResponse([]byte(pgLsJSON), "", nil)
+	l, err := parseStats(r)
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+	s := l[0]
+	assert.Equal(t, "2.6", s.PGID)
+	assert.True(t, s.State.Has(StateActive, StateClean, StateInconsistent))
+	assert.Equal(t, "21'3", s.Version)
+	assert.Equal(t, "2023-08-01T10:39:52.212718+0000", s.LastDeepScrubStamp)
+	assert.EqualValues(t, 3, s.LogSize)
+	assert.EqualValues(t, 1, s.StatSum.NumObjects)
+	assert.EqualValues(t, 1, s.StatSum.NumScrubErrors)
+	assert.EqualValues(t, 1, s.StatSum.NumDeepScrubErrors)
+	assert.Equal(t, []int64{2, 0, 1}, s.Acting)
+
+	r = commands.NewResponse([]byte(`{"pg_ready":true,"pg_stats":[]}`), "", nil)
+	l, err = parseStats(r)
+	require.NoError(t, err)
+	assert.Len(t, l, 0)
+
+	r = commands.NewResponse([]byte(`{"pg_ready":`), "", nil)
+	_, err = parseStats(r)
+	assert.Error(t, err)
+}
+
+func TestParseStuck(t *testing.T) {
+	r := commands.NewResponse(nil, "ok", nil)
+	l, err := parseStuck(r)
+	require.NoError(t, err)
+	assert.Len(t, l, 0)
+
+	r = commands.NewResponse([]byte(pgsBriefJSON), "", nil)
+	l, err = parseStuck(r)
+	require.NoError(t, err)
+	assert.Len(t, l, 2)
+
+	r = commands.NewResponse([]byte(`{"stuck_pg_stats":`+pgsBriefJSON+`}`), "", nil)
+	l, err = parseStuck(r)
+	require.NoError(t, err)
+	assert.Len(t, l, 2)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	l, err = parseStuck(r)
+	assert.Error(t, err)
+	assert.Nil(t, l)
+}
+
+func TestListPoolAndOSD(t *testing.T) {
+	pa := NewFromConn(nil)
+	osd := int64(0)
+	_, err := pa.List(&ListOptions{Pool: "rbd", OSD: &osd})
+	assert.Equal(t, errPoolAndOSD, err)
+}
+
+func TestList(t *testing.T) {
+	pa := getAdmin(t)
+
+	briefs, err := pa.DumpBrief()
+	require.NoError(t, err)
+	require.NotEmpty(t, briefs)
+
+	stats, err := pa.List(nil)
+	require.NoError(t, err)
+	assert.Len(t, stats, len(briefs))
+
+	osd := briefs[0].ActingPrimary
+	stats, err = pa.List(&ListOptions{OSD: &osd})
+	require.NoError(t, err)
+	assert.NotEmpty(t, stats)
+	for _, s := range stats {
+		assert.Contains(t, s.Acting, osd)
+	}
+
+	stats, err = pa.List(&ListOptions{States: []string{StateInconsistent}})
+	require.NoError(t, err)
+	for _, s := range stats {
+		assert.True(t, s.State.Has(StateInconsistent))
+	}
+
+	_, err = pa.List(&ListOptions{Pool: "go-ceph-no-such-pool"})
+	assert.Error(t, err)
+
+	_, err = pa.DumpStuck(time.Hour, StuckInactive, StuckStale)
+	assert.NoError(t, err)
+}
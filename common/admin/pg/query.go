@@ -0,0 +1,64 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"errors"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var errNoPGCommand = errors.New("connection can not send commands to PGs")
+
+// RecoveryState is a state of the peering state machine of a PG, entered at
+// EnterTime.
+type RecoveryState struct {
+	Name      string `json:"name"`
+	EnterTime string `json:"enter_time"`
+}
+
+// PGInfo contains the information about a PG held by its primary OSD.
+type PGInfo struct {
+	PGID         string `json:"pgid"`
+	LastUpdate   string `json:"last_update"`
+	LastComplete string `json:"last_complete"`
+	Stats        PGStat `json:"stats"`
+}
+
+// PGQuery is the detailed state of a PG as reported by its primary OSD.
+type PGQuery struct {
+	State         PGState         `json:"state"`
+	Epoch         int64           `json:"epoch"`
+	Up            []int64         `json:"up"`
+	Acting        []int64         `json:"acting"`
+	Info          PGInfo          `json:"info"`
+	RecoveryState []RecoveryState `json:"recovery_state"`
+}
+
+func parseQuery(res commands.Response) (*PGQuery, error) {
+	q := &PGQuery{}
+	if err := res.NoStatus().Unmarshal(q).End(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// Query returns the detailed state of the PG with the given ID, like "2.1f",
+// as reported by its primary OSD. The Admin must be created by
+// NewFromPGConn.
+//
+// Similar To:
+//
+//	ceph pg <pgid> query
+func (pa *Admin) Query(pgid string) (*PGQuery, error) {
+	if pa.pgConn == nil {
+		return nil, errNoPGCommand
+	}
+	m := map[string]string{
+		"prefix": "query",
+		"pgid":   pgid,
+		"format": "json",
+	}
+	return parseQuery(commands.MarshalPGCommand(pa.pgConn, pgid, m))
+}
@@ -0,0 +1,104 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var pgQueryJSON = `
+{
+  "snap_trimq": "[]",
+  "snap_trimq_len": 0,
+  "state": "active+clean",
+  "epoch": 21,
+  "up": [0],
+  "acting": [0],
+  "acting_recovery_backfill": ["0"],
+  "info": {
+    "pgid": "2.7",
+    "last_update": "21'3",
+    "last_complete": "21'3",
+    "log_tail": "0'0",
+    "stats": {
+      "version": "21'3",
+      "state": "active+clean",
+      "last_scrub_stamp": "2023-08-01T10:04:21.196342+0000",
+      "stat_sum": {
+        "num_bytes": 19,
+        "num_objects": 1
+      },
+      "up": [0],
+      "acting": [0],
+      "up_primary": 0,
+      "acting_primary": 0
+    }
+  },
+  "peer_info": [],
+  "recovery_state": [
+    {
+      "name": "Started/Primary/Active",
+      "enter_time": "2023-08-01T10:04:23.211035+0000"
+    },
+    {
+      "name": "Started",
+      "enter_time": "2023-08-01T10:04:22.199718+0000"
+    }
+  ],
+  "agent_state": {}
+}
+`
+
+func TestParseQuery(t *testing.T) {
+	r := commands.NewResponse([]byte(pgQueryJSON), "", nil)
+	q, err := parseQuery(r)
+	require.NoError(t, err)
+	assert.Equal(t, PGState{StateActive, StateClean}, q.State)
+	assert.EqualValues(t, 21, q.Epoch)
+	assert.Equal(t, "2.7", q.Info.PGID)
+	assert.Equal(t, "21'3", q.Info.LastComplete)
+	assert.EqualValues(t, 1, q.Info.Stats.StatSum.NumObjects)
+	require.Len(t, q.RecoveryState, 2)
+	assert.Equal(t, "Started/Primary/Active", q.RecoveryState[0].Name)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	q, err = parseQuery(r)
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+type monOnlyCommander struct{}
+
+func (monOnlyCommander) MonCommand([]byte) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+func (monOnlyCommander) MgrCommand([][]byte) ([]byte, string, error) {
+	return nil, "", nil
+}
+
+func TestQueryNoPGCommand(t *testing.T) {
+	pa := NewFromConn(monOnlyCommander{})
+	_, err := pa.Query("2.7")
+	assert.Equal(t, errNoPGCommand, err)
+}
+
+func TestQuery(t *testing.T) {
+	pa := NewFromPGConn(radosConnector.GetConn(t))
+	briefs, err := pa.DumpBrief()
+	require.NoError(t, err)
+	require.NotEmpty(t, briefs)
+
+	q, err := pa.Query(briefs[0].PGID)
+	require.NoError(t, err)
+	assert.Equal(t, briefs[0].PGID, q.Info.PGID)
+	assert.Equal(t, briefs[0].Acting, q.Acting)
+	assert.NotEmpty(t, q.RecoveryState)
+}
@@ -0,0 +1,49 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func (pa *Admin) instruct(prefix, pgid string) error {
+	m := map[string]string{
+		"prefix": prefix,
+		"pgid":   pgid,
+		"format": "json",
+	}
+	return commands.MarshalMgrCommand(pa.conn, m).NoBody().End()
+}
+
+// Scrub instructs the primary OSD of the PG with the given ID to scrub the
+// PG. The scrub is performed asynchronously.
+//
+// Similar To:
+//
+//	ceph pg scrub <pgid>
+func (pa *Admin) Scrub(pgid string) error {
+	return pa.instruct("pg scrub", pgid)
+}
+
+// DeepScrub instructs the primary OSD of the PG with the given ID to deep
+// scrub the PG, reading and comparing the data of all copies. The scrub is
+// performed asynchronously.
+//
+// Similar To:
+//
+//	ceph pg deep-scrub <pgid>
+func (pa *Admin) DeepScrub(pgid string) error {
+	return pa.instruct("pg deep-scrub", pgid)
+}
+
+// Repair instructs the primary OSD of the PG with the given ID to repair
+// the inconsistencies of the PG found by scrubbing. The repair is performed
+// asynchronously.
+//
+// Similar To:
+//
+//	ceph pg repair <pgid>
+func (pa *Admin) Repair(pgid string) error {
+	return pa.instruct("pg repair", pgid)
+}
@@ -0,0 +1,25 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScrubRepair(t *testing.T) {
+	pa := getAdmin(t)
+	briefs, err := pa.DumpBrief()
+	require.NoError(t, err)
+	require.NotEmpty(t, briefs)
+	pgid := briefs[0].PGID
+
+	assert.NoError(t, pa.Scrub(pgid))
+	assert.NoError(t, pa.DeepScrub(pgid))
+	assert.NoError(t, pa.Repair(pgid))
+
+	assert.Error(t, pa.Scrub("999.0"))
+}
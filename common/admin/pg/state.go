@@ -0,0 +1,88 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Names of commonly seen PG states.
+const (
+	StateActive          = "active"
+	StateClean           = "clean"
+	StateDown            = "down"
+	StatePeering         = "peering"
+	StatePeered          = "peered"
+	StateStale           = "stale"
+	StateDegraded        = "degraded"
+	StateUndersized      = "undersized"
+	StateRemapped        = "remapped"
+	StateInconsistent    = "inconsistent"
+	StateIncomplete      = "incomplete"
+	StateRecovering      = "recovering"
+	StateRecoveryWait    = "recovery_wait"
+	StateBackfilling     = "backfilling"
+	StateBackfillWait    = "backfill_wait"
+	StateScrubbing       = "scrubbing"
+	StateDeep            = "deep"
+	StateRepair          = "repair"
+	StateSnapTrim        = "snaptrim"
+	StateUnknown         = "unknown"
+	StateCreating        = "creating"
+	StateBackfillTooFull = "backfill_toofull"
+	StateRecoveryTooFull = "recovery_toofull"
+	StateForcedRecovery  = "forced_recovery"
+)
+
+const stateSeparator = "+"
+
+// PGState is the state of a PG. It consists of the names of the states the
+// PG is in, like "active" and "clean", reported by ceph joined by "+".
+type PGState []string
+
+// ParsePGState parses a "+"-joined state string, like "active+clean".
+func ParsePGState(s string) PGState {
+	if s == "" {
+		return PGState{}
+	}
+	return PGState(strings.Split(s, stateSeparator))
+}
+
+// String returns the "+"-joined state string as reported by ceph.
+func (s PGState) String() string {
+	return strings.Join(s, stateSeparator)
+}
+
+// Has returns true if the PG is in all of the given states.
+func (s PGState) Has(states ...string) bool {
+	for _, state := range states {
+		found := false
+		for _, v := range s {
+			if v == state {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (s *PGState) UnmarshalJSON(b []byte) error {
+	var raw string
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*s = ParsePGState(raw)
+	return nil
+}
+
+// MarshalJSON implements the json Marshaler interface.
+func (s PGState) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
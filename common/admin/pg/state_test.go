@@ -0,0 +1,40 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePGState(t *testing.T) {
+	s := ParsePGState("active+clean+scrubbing+deep")
+	assert.Equal(t, PGState{"active", "clean", "scrubbing", "deep"}, s)
+	assert.True(t, s.Has(StateActive))
+	assert.True(t, s.Has(StateScrubbing, StateDeep))
+	assert.False(t, s.Has(StateInconsistent))
+	assert.False(t, s.Has(StateActive, StateInconsistent))
+	assert.Equal(t, "active+clean+scrubbing+deep", s.String())
+
+	s = ParsePGState("")
+	assert.Len(t, s, 0)
+	assert.Equal(t, "", s.String())
+}
+
+func TestPGStateJSON(t *testing.T) {
+	var s PGState
+	err := json.Unmarshal([]byte(`"active+clean+inconsistent"`), &s)
+	require.NoError(t, err)
+	assert.True(t, s.Has(StateActive, StateClean, StateInconsistent))
+
+	b, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.Equal(t, `"active+clean+inconsistent"`, string(b))
+
+	err = json.Unmarshal([]byte(`["active"]`), &s)
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func getAdmin(t *testing.T) *Admin {
+	return NewFromConn(radosConnector.Get(t))
+}
+
+func genName(prefix string) string {
+	return prefix + "-" + uuid.Must(uuid.NewV4()).String()
+}
+
+// createPool creates a small replicated pool for the test and registers its
+// removal.
+func createPool(t *testing.T, pa *Admin) string {
+	name := genName("go-ceph-pool")
+	err := pa.Create(name, &CreateOptions{PGNum: 8, AutoscaleMode: "off"})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pa.Remove(name)
+	})
+	return name
+}
@@ -0,0 +1,6 @@
+/*
+Package pool from common/admin contains a set of APIs used to create,
+configure and remove the pools of a Ceph cluster as well as the erasure code
+profiles used by erasure coded pools.
+*/
+package pool
@@ -0,0 +1,172 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+const (
+	ecKeyK                  = "k"
+	ecKeyM                  = "m"
+	ecKeyPlugin             = "plugin"
+	ecKeyTechnique          = "technique"
+	ecKeyCrushRoot          = "crush-root"
+	ecKeyCrushFailureDomain = "crush-failure-domain"
+	ecKeyCrushDeviceClass   = "crush-device-class"
+)
+
+// ErasureCodeProfile describes how the objects of erasure coded pools are
+// split into chunks and placed. The zero value of a field selects the
+// default of the cluster.
+type ErasureCodeProfile struct {
+	// K is the number of data chunks.
+	K int
+	// M is the number of coding chunks.
+	M int
+	// Plugin is the erasure code plugin, for example "jerasure" or "isa".
+	Plugin string
+	// Technique is the plugin specific erasure code technique.
+	Technique string
+	// CrushRoot is the name of the CRUSH bucket the placement starts at.
+	CrushRoot string
+	// CrushFailureDomain is the type of the CRUSH buckets the chunks are
+	// spread across, for example "host".
+	CrushFailureDomain string
+	// CrushDeviceClass optionally limits the placement to the OSDs of the
+	// device class.
+	CrushDeviceClass string
+	// Extra contains any other, plugin specific, settings of the profile.
+	Extra map[string]string
+}
+
+func (p *ErasureCodeProfile) settings() []string {
+	m := map[string]string{}
+	for k, v := range p.Extra {
+		m[k] = v
+	}
+	if p.K > 0 {
+		m[ecKeyK] = strconv.Itoa(p.K)
+	}
+	if p.M > 0 {
+		m[ecKeyM] = strconv.Itoa(p.M)
+	}
+	for k, v := range map[string]string{
+		ecKeyPlugin:             p.Plugin,
+		ecKeyTechnique:          p.Technique,
+		ecKeyCrushRoot:          p.CrushRoot,
+		ecKeyCrushFailureDomain: p.CrushFailureDomain,
+		ecKeyCrushDeviceClass:   p.CrushDeviceClass,
+	} {
+		if v != "" {
+			m[k] = v
+		}
+	}
+	s := make([]string, 0, len(m))
+	for k, v := range m {
+		s = append(s, k+"="+v)
+	}
+	sort.Strings(s)
+	return s
+}
+
+func parseErasureCodeProfile(res commands.Response) (*ErasureCodeProfile, error) {
+	m := map[string]string{}
+	if err := res.NoStatus().Unmarshal(&m).End(); err != nil {
+		return nil, err
+	}
+	p := &ErasureCodeProfile{Extra: map[string]string{}}
+	for k, v := range m {
+		var err error
+		switch k {
+		case ecKeyK:
+			p.K, err = strconv.Atoi(v)
+		case ecKeyM:
+			p.M, err = strconv.Atoi(v)
+		case ecKeyPlugin:
+			p.Plugin = v
+		case ecKeyTechnique:
+			p.Technique = v
+		case ecKeyCrushRoot:
+			p.CrushRoot = v
+		case ecKeyCrushFailureDomain:
+			p.CrushFailureDomain = v
+		case ecKeyCrushDeviceClass:
+			p.CrushDeviceClass = v
+		default:
+			p.Extra[k] = v
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid erasure code profile value %s=%q: %w", k, v, err)
+		}
+	}
+	return p, nil
+}
+
+// SetErasureCodeProfile creates or updates the erasure code profile with the
+// given name. Changing an existing profile requires force to be true. The
+// pools already using the profile are not affected by the change.
+//
+// Similar To:
+//
+//	ceph osd erasure-code-profile set <name> [<key=value>...] [--force]
+func (pa *Admin) SetErasureCodeProfile(name string, p ErasureCodeProfile, force bool) error {
+	m := map[string]interface{}{
+		"prefix":  "osd erasure-code-profile set",
+		"name":    name,
+		"profile": p.settings(),
+		"format":  "json",
+	}
+	if force {
+		m["force"] = true
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
+
+// GetErasureCodeProfile returns the erasure code profile with the given
+// name.
+//
+// Similar To:
+//
+//	ceph osd erasure-code-profile get <name>
+func (pa *Admin) GetErasureCodeProfile(name string) (*ErasureCodeProfile, error) {
+	m := map[string]string{
+		"prefix": "osd erasure-code-profile get",
+		"name":   name,
+		"format": "json",
+	}
+	return parseErasureCodeProfile(commands.MarshalMonCommand(pa.conn, m))
+}
+
+// ListErasureCodeProfiles returns the names of the erasure code profiles.
+//
+// Similar To:
+//
+//	ceph osd erasure-code-profile ls
+func (pa *Admin) ListErasureCodeProfiles() ([]string, error) {
+	m := map[string]string{
+		"prefix": "osd erasure-code-profile ls",
+		"format": "json",
+	}
+	return parseNames(commands.MarshalMonCommand(pa.conn, m))
+}
+
+// RemoveErasureCodeProfile removes the erasure code profile with the given
+// name. The profile must not be in use by any pool.
+//
+// Similar To:
+//
+//	ceph osd erasure-code-profile rm <name>
+func (pa *Admin) RemoveErasureCodeProfile(name string) error {
+	m := map[string]string{
+		"prefix": "osd erasure-code-profile rm",
+		"name":   name,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
@@ -0,0 +1,94 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestErasureCodeProfileSettings(t *testing.T) {
+	p := ErasureCodeProfile{
+		K:                  4,
+		M:                  2,
+		Plugin:             "jerasure",
+		CrushFailureDomain: "osd",
+		Extra:              map[string]string{"w": "8"},
+	}
+	assert.Equal(t,
+		[]string{"crush-failure-domain=osd", "k=4", "m=2", "plugin=jerasure", "w=8"},
+		p.settings())
+	assert.Equal(t, []string{}, (&ErasureCodeProfile{}).settings())
+}
+
+func TestParseErasureCodeProfile(t *testing.T) {
+	r := commands.NewResponse([]byte(`{
+  "crush-device-class": "",
+  "crush-failure-domain": "host",
+  "crush-root": "default",
+  "jerasure-per-chunk-alignment": "false",
+  "k": "2",
+  "m": "1",
+  "plugin": "jerasure",
+  "technique": "reed_sol_van",
+  "w": "8"
+}`), "", nil)
+	p, err := parseErasureCodeProfile(r)
+	require.NoError(t, err)
+	assert.Equal(t, 2, p.K)
+	assert.Equal(t, 1, p.M)
+	assert.Equal(t, "jerasure", p.Plugin)
+	assert.Equal(t, "reed_sol_van", p.Technique)
+	assert.Equal(t, "default", p.CrushRoot)
+	assert.Equal(t, "host", p.CrushFailureDomain)
+	assert.Equal(t, "", p.CrushDeviceClass)
+	assert.Equal(t, map[string]string{
+		"jerasure-per-chunk-alignment": "false",
+		"w":                            "8",
+	}, p.Extra)
+
+	r = commands.NewResponse([]byte(`{"k": "two"}`), "", nil)
+	_, err = parseErasureCodeProfile(r)
+	assert.Error(t, err)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	_, err = parseErasureCodeProfile(r)
+	assert.Error(t, err)
+}
+
+func TestErasureCodeProfiles(t *testing.T) {
+	pa := getAdmin(t)
+	name := genName("go-ceph-ec")
+
+	p := ErasureCodeProfile{K: 2, M: 1, CrushFailureDomain: "osd"}
+	err := pa.SetErasureCodeProfile(name, p, false)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, pa.RemoveErasureCodeProfile(name))
+	}()
+
+	names, err := pa.ListErasureCodeProfiles()
+	require.NoError(t, err)
+	assert.Contains(t, names, name)
+
+	got, err := pa.GetErasureCodeProfile(name)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.K)
+	assert.Equal(t, 1, got.M)
+	assert.Equal(t, "osd", got.CrushFailureDomain)
+
+	p.M = 2
+	err = pa.SetErasureCodeProfile(name, p, false)
+	assert.Error(t, err)
+	err = pa.SetErasureCodeProfile(name, p, true)
+	require.NoError(t, err)
+	got, err = pa.GetErasureCodeProfile(name)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.M)
+}
@@ -0,0 +1,207 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Type is the type of a pool.
+type Type string
+
+const (
+	// TypeReplicated is the type of pools that store replicas of objects.
+	TypeReplicated = Type("replicated")
+	// TypeErasure is the type of pools that store erasure coded objects.
+	TypeErasure = Type("erasure")
+)
+
+// CreateOptions are the optional settings of a new pool. The zero value of
+// a field selects the default of the cluster.
+type CreateOptions struct {
+	// Type of the pool.
+	Type Type
+	// PGNum is the number of placement groups of the pool.
+	PGNum int
+	// PGPNum is the number of placement groups used for placement.
+	PGPNum int
+	// CrushRule is the name of the CRUSH rule of the pool.
+	CrushRule string
+	// ErasureCodeProfile is the name of the erasure code profile of an
+	// erasure coded pool.
+	ErasureCodeProfile string
+	// Size is the number of replicas of a replicated pool.
+	Size int
+	// ExpectedNumObjects is the expected number of objects of the pool,
+	// used to pre-split the placement group directories of filestore OSDs.
+	ExpectedNumObjects int64
+	// AutoscaleMode is the PG autoscale mode of the pool: "on", "off" or
+	// "warn".
+	AutoscaleMode string
+}
+
+// Create creates a new pool with the given name. The options may be nil to
+// use the defaults of the cluster. It is not an error if the pool already
+// exists.
+//
+// Similar To:
+//
+//	ceph osd pool create <pool> [<pg_num>] [<pgp_num>] [<type>] ...
+func (pa *Admin) Create(name string, o *CreateOptions) error {
+	m := map[string]interface{}{
+		"prefix": "osd pool create",
+		"pool":   name,
+		"format": "json",
+	}
+	if o != nil {
+		if o.Type != "" {
+			m["pool_type"] = o.Type
+		}
+		if o.PGNum > 0 {
+			m["pg_num"] = o.PGNum
+		}
+		if o.PGPNum > 0 {
+			m["pgp_num"] = o.PGPNum
+		}
+		if o.CrushRule != "" {
+			m["rule"] = o.CrushRule
+		}
+		if o.ErasureCodeProfile != "" {
+			m["erasure_code_profile"] = o.ErasureCodeProfile
+		}
+		if o.Size > 0 {
+			m["size"] = o.Size
+		}
+		if o.ExpectedNumObjects > 0 {
+			m["expected_num_objects"] = o.ExpectedNumObjects
+		}
+		if o.AutoscaleMode != "" {
+			m["autoscale_mode"] = o.AutoscaleMode
+		}
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
+
+// Remove removes the pool with the given name and all of its objects. The
+// removal of pools must be allowed by the mon_allow_pool_delete option of
+// the monitors.
+//
+// Similar To:
+//
+//	ceph osd pool rm <pool> <pool> --yes-i-really-really-mean-it
+func (pa *Admin) Remove(name string) error {
+	m := map[string]interface{}{
+		"prefix":                      "osd pool rm",
+		"pool":                        name,
+		"pool2":                       name,
+		"yes_i_really_really_mean_it": true,
+		"format":                      "json",
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
+
+// Rename changes the name of a pool.
+//
+// Similar To:
+//
+//	ceph osd pool rename <srcpool> <destpool>
+func (pa *Admin) Rename(name, newName string) error {
+	m := map[string]string{
+		"prefix":   "osd pool rename",
+		"srcpool":  name,
+		"destpool": newName,
+		"format":   "json",
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
+
+func parseNames(res commands.Response) ([]string, error) {
+	var names []string
+	if err := res.NoStatus().Unmarshal(&names).End(); err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// List returns the names of the pools.
+//
+// Similar To:
+//
+//	ceph osd pool ls
+func (pa *Admin) List() ([]string, error) {
+	m := map[string]string{
+		"prefix": "osd pool ls",
+		"format": "json",
+	}
+	return parseNames(commands.MarshalMonCommand(pa.conn, m))
+}
+
+// Detail contains the details of a pool.
+type Detail struct {
+	PoolID               int64                        `json:"pool_id"`
+	PoolName             string                       `json:"pool_name"`
+	CreateTime           string                       `json:"create_time"`
+	FlagsNames           string                       `json:"flags_names"`
+	Type                 int                          `json:"type"`
+	Size                 int                          `json:"size"`
+	MinSize              int                          `json:"min_size"`
+	CrushRule            int64                        `json:"crush_rule"`
+	PGAutoscaleMode      string                       `json:"pg_autoscale_mode"`
+	PGNum                int                          `json:"pg_num"`
+	PGPlacementNum       int                          `json:"pg_placement_num"`
+	PGNumTarget          int                          `json:"pg_num_target"`
+	PGPlacementNumTarget int                          `json:"pg_placement_num_target"`
+	QuotaMaxBytes        uint64                       `json:"quota_max_bytes"`
+	QuotaMaxObjects      uint64                       `json:"quota_max_objects"`
+	ErasureCodeProfile   string                       `json:"erasure_code_profile"`
+	ApplicationMetadata  map[string]map[string]string `json:"application_metadata"`
+}
+
+// IsErasure returns true if the pool is an erasure coded pool.
+func (d Detail) IsErasure() bool {
+	// the values of the pg_pool_t::TYPE_* constants of ceph
+	return d.Type == 3
+}
+
+func parseDetails(res commands.Response) ([]Detail, error) {
+	var details []Detail
+	if err := res.NoStatus().Unmarshal(&details).End(); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+// ListDetails returns the details of all pools.
+//
+// Similar To:
+//
+//	ceph osd pool ls detail
+func (pa *Admin) ListDetails() ([]Detail, error) {
+	m := map[string]string{
+		"prefix": "osd pool ls",
+		"detail": "detail",
+		"format": "json",
+	}
+	return parseDetails(commands.MarshalMonCommand(pa.conn, m))
+}
+
+// ApplicationEnable associates the application with the given name with the
+// pool. Associating a pool with more than one application requires force
+// to be true.
+//
+// Similar To:
+//
+//	ceph osd pool application enable <pool> <app> [--yes-i-really-mean-it]
+func (pa *Admin) ApplicationEnable(name, app string, force bool) error {
+	m := map[string]interface{}{
+		"prefix": "osd pool application enable",
+		"pool":   name,
+		"app":    app,
+		"format": "json",
+	}
+	if force {
+		m["yes_i_really_mean_it"] = true
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
@@ -0,0 +1,163 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var poolLsDetailJSON = `
+[
+  {
+    "pool_id": 1,
+    "pool_name": ".mgr",
+    "create_time": "2023-08-01T10:03:18.162104+0000",
+    "flags": 1,
+    "flags_names": "hashpspool",
+    "type": 1,
+    "size": 3,
+    "min_size": 2,
+    "crush_rule": 0,
+    "pg_autoscale_mode": "on",
+    "pg_num": 1,
+    "pg_placement_num": 1,
+    "pg_placement_num_target": 1,
+    "pg_num_target": 1,
+    "quota_max_bytes": 0,
+    "quota_max_objects": 0,
+    "erasure_code_profile": "",
+    "application_metadata": {
+      "mgr": {}
+    }
+  },
+  {
+    "pool_id": 4,
+    "pool_name": "ecpool",
+    "create_time": "2023-08-01T11:13:08.172104+0000",
+    "flags": 8193,
+    "flags_names": "hashpspool,ec_overwrites",
+    "type": 3,
+    "size": 3,
+    "min_size": 2,
+    "crush_rule": 1,
+    "pg_autoscale_mode": "warn",
+    "pg_num": 32,
+    "pg_placement_num": 32,
+    "pg_placement_num_target": 32,
+    "pg_num_target": 32,
+    "quota_max_bytes": 1073741824,
+    "quota_max_objects": 1000,
+    "erasure_code_profile": "k2m1",
+    "application_metadata": {
+      "rbd": {}
+    }
+  }
+]
+`
+
+func TestParseDetails(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(poolLsDetailJSON), "", nil)
+		details, err := parseDetails(r)
+		require.NoError(t, err)
+		require.Len(t, details, 2)
+		assert.Equal(t, ".mgr", details[0].PoolName)
+		assert.False(t, details[0].IsErasure())
+		assert.Equal(t, 3, details[0].Size)
+		assert.Contains(t, details[0].ApplicationMetadata, "mgr")
+		assert.EqualValues(t, 4, details[1].PoolID)
+		assert.True(t, details[1].IsErasure())
+		assert.Equal(t, "k2m1", details[1].ErasureCodeProfile)
+		assert.Equal(t, 32, details[1].PGNum)
+		assert.EqualValues(t, 1000, details[1].QuotaMaxObjects)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		details, err := parseDetails(r)
+		assert.Error(t, err)
+		assert.Nil(t, details)
+	})
+}
+
+func findDetail(t *testing.T, pa *Admin, name string) *Detail {
+	details, err := pa.ListDetails()
+	require.NoError(t, err)
+	for i := range details {
+		if details[i].PoolName == name {
+			return &details[i]
+		}
+	}
+	return nil
+}
+
+func TestCreateRemove(t *testing.T) {
+	pa := getAdmin(t)
+	name := genName("go-ceph-pool")
+
+	err := pa.Create(name, &CreateOptions{
+		Type:          TypeReplicated,
+		PGNum:         8,
+		PGPNum:        8,
+		CrushRule:     "replicated_rule",
+		AutoscaleMode: "off",
+	})
+	require.NoError(t, err)
+	names, err := pa.List()
+	require.NoError(t, err)
+	assert.Contains(t, names, name)
+
+	d := findDetail(t, pa, name)
+	require.NotNil(t, d)
+	assert.Equal(t, 8, d.PGNum)
+	assert.Equal(t, "off", d.PGAutoscaleMode)
+	assert.False(t, d.IsErasure())
+
+	err = pa.Remove(name)
+	require.NoError(t, err)
+	names, err = pa.List()
+	require.NoError(t, err)
+	assert.NotContains(t, names, name)
+
+	err = pa.Create(name, &CreateOptions{CrushRule: "no_such_rule"})
+	assert.Error(t, err)
+}
+
+func TestRename(t *testing.T) {
+	pa := getAdmin(t)
+	name := createPool(t, pa)
+	newName := genName("go-ceph-renamed")
+
+	err := pa.Rename(name, newName)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = pa.Remove(newName)
+	})
+	names, err := pa.List()
+	require.NoError(t, err)
+	assert.Contains(t, names, newName)
+	assert.NotContains(t, names, name)
+}
+
+func TestApplicationEnable(t *testing.T) {
+	pa := getAdmin(t)
+	name := createPool(t, pa)
+
+	err := pa.ApplicationEnable(name, "rbd", false)
+	require.NoError(t, err)
+	err = pa.ApplicationEnable(name, "rgw", false)
+	assert.Error(t, err)
+	err = pa.ApplicationEnable(name, "rgw", true)
+	require.NoError(t, err)
+
+	d := findDetail(t, pa, name)
+	require.NotNil(t, d)
+	assert.Contains(t, d.ApplicationMetadata, "rbd")
+	assert.Contains(t, d.ApplicationMetadata, "rgw")
+}
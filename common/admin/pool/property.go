@@ -0,0 +1,104 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Property is the name of a property of a pool.
+type Property string
+
+const (
+	// PropertySize is the number of replicas of the objects.
+	PropertySize = Property("size")
+	// PropertyMinSize is the minimum number of replicas required for I/O.
+	PropertyMinSize = Property("min_size")
+	// PropertyPGNum is the number of placement groups.
+	PropertyPGNum = Property("pg_num")
+	// PropertyPGPNum is the number of placement groups used for placement.
+	PropertyPGPNum = Property("pgp_num")
+	// PropertyCrushRule is the name of the CRUSH rule.
+	PropertyCrushRule = Property("crush_rule")
+	// PropertyPGAutoscaleMode is the PG autoscale mode.
+	PropertyPGAutoscaleMode = Property("pg_autoscale_mode")
+	// PropertyTargetSizeBytes is the expected size of the pool, used by the
+	// PG autoscaler.
+	PropertyTargetSizeBytes = Property("target_size_bytes")
+	// PropertyTargetSizeRatio is the expected share of the capacity of the
+	// cluster used by the pool, used by the PG autoscaler.
+	PropertyTargetSizeRatio = Property("target_size_ratio")
+	// PropertyCompressionMode is the inline compression mode.
+	PropertyCompressionMode = Property("compression_mode")
+	// PropertyCompressionAlgorithm is the inline compression algorithm.
+	PropertyCompressionAlgorithm = Property("compression_algorithm")
+	// PropertyNoDelete prevents the removal of the pool if true.
+	PropertyNoDelete = Property("nodelete")
+	// PropertyBulk marks the pool as expected to be large, for the PG
+	// autoscaler.
+	PropertyBulk = Property("bulk")
+)
+
+func parseProperty(res commands.Response, prop Property) (string, error) {
+	var m map[string]json.RawMessage
+	if err := res.NoStatus().Unmarshal(&m).End(); err != nil {
+		return "", err
+	}
+	raw, ok := m[string(prop)]
+	if !ok {
+		return "", fmt.Errorf("pool property %q missing from response", prop)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s, nil
+	}
+	return string(raw), nil
+}
+
+// Get returns the value of the given property of the pool with the given
+// name. Numeric and boolean values are returned in their JSON text form,
+// for example "3" or "true".
+//
+// Similar To:
+//
+//	ceph osd pool get <pool> <var>
+func (pa *Admin) Get(name string, prop Property) (string, error) {
+	m := map[string]string{
+		"prefix": "osd pool get",
+		"pool":   name,
+		"var":    string(prop),
+		"format": "json",
+	}
+	return parseProperty(commands.MarshalMonCommand(pa.conn, m), prop)
+}
+
+// GetInt returns the value of the given numeric property of the pool with
+// the given name.
+func (pa *Admin) GetInt(name string, prop Property) (int64, error) {
+	v, err := pa.Get(name, prop)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// Set sets the value of the given property of the pool with the given name.
+//
+// Similar To:
+//
+//	ceph osd pool set <pool> <var> <val>
+func (pa *Admin) Set(name string, prop Property, value string) error {
+	m := map[string]string{
+		"prefix": "osd pool set",
+		"pool":   name,
+		"var":    string(prop),
+		"val":    value,
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
@@ -0,0 +1,68 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestParseProperty(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"pool":"rbd","pool_id":2,"size":3}`), "", nil)
+	v, err := parseProperty(r, PropertySize)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", v)
+
+	r = commands.NewResponse([]byte(`{"pool":"rbd","pool_id":2,"crush_rule":"replicated_rule"}`), "", nil)
+	v, err = parseProperty(r, PropertyCrushRule)
+	assert.NoError(t, err)
+	assert.Equal(t, "replicated_rule", v)
+
+	r = commands.NewResponse([]byte(`{"pool":"rbd","pool_id":2,"nodelete":false}`), "", nil)
+	v, err = parseProperty(r, PropertyNoDelete)
+	assert.NoError(t, err)
+	assert.Equal(t, "false", v)
+
+	r = commands.NewResponse([]byte(`{"pool":"rbd","pool_id":2}`), "", nil)
+	_, err = parseProperty(r, PropertySize)
+	assert.Error(t, err)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	_, err = parseProperty(r, PropertySize)
+	assert.Error(t, err)
+}
+
+func TestGetSet(t *testing.T) {
+	pa := getAdmin(t)
+	name := createPool(t, pa)
+
+	v, err := pa.Get(name, PropertyCrushRule)
+	require.NoError(t, err)
+	assert.Equal(t, "replicated_rule", v)
+
+	err = pa.Set(name, PropertyPGNum, "16")
+	require.NoError(t, err)
+	n, err := pa.GetInt(name, PropertyPGNum)
+	require.NoError(t, err)
+	assert.EqualValues(t, 16, n)
+
+	err = pa.Set(name, PropertyNoDelete, "true")
+	require.NoError(t, err)
+	v, err = pa.Get(name, PropertyNoDelete)
+	require.NoError(t, err)
+	assert.Equal(t, "true", v)
+	assert.Error(t, pa.Remove(name))
+	err = pa.Set(name, PropertyNoDelete, "false")
+	require.NoError(t, err)
+
+	err = pa.Set(name, Property("no_such_property"), "1")
+	assert.Error(t, err)
+	_, err = pa.Get(genName("no-such-pool"), PropertySize)
+	assert.Error(t, err)
+}
@@ -0,0 +1,68 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"strconv"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// QuotaField selects the quota set by SetQuota.
+type QuotaField string
+
+const (
+	// QuotaMaxObjects is the maximum number of objects of a pool.
+	QuotaMaxObjects = QuotaField("max_objects")
+	// QuotaMaxBytes is the maximum number of bytes stored in a pool.
+	QuotaMaxBytes = QuotaField("max_bytes")
+)
+
+// Quota contains the quotas of a pool. A value of zero means that there is
+// no quota.
+type Quota struct {
+	PoolName   string `json:"pool_name"`
+	PoolID     int64  `json:"pool_id"`
+	MaxObjects uint64 `json:"quota_max_objects"`
+	MaxBytes   uint64 `json:"quota_max_bytes"`
+}
+
+// SetQuota sets the given quota of the pool with the given name. A value of
+// zero removes the quota.
+//
+// Similar To:
+//
+//	ceph osd pool set-quota <pool> max_objects|max_bytes <val>
+func (pa *Admin) SetQuota(name string, field QuotaField, value uint64) error {
+	m := map[string]string{
+		"prefix": "osd pool set-quota",
+		"pool":   name,
+		"field":  string(field),
+		"val":    strconv.FormatUint(value, 10),
+		"format": "json",
+	}
+	return commands.MarshalMonCommand(pa.conn, m).NoBody().End()
+}
+
+func parseQuota(res commands.Response) (*Quota, error) {
+	q := &Quota{}
+	if err := res.NoStatus().Unmarshal(q).End(); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// GetQuota returns the quotas of the pool with the given name.
+//
+// Similar To:
+//
+//	ceph osd pool get-quota <pool>
+func (pa *Admin) GetQuota(name string) (*Quota, error) {
+	m := map[string]string{
+		"prefix": "osd pool get-quota",
+		"pool":   name,
+		"format": "json",
+	}
+	return parseQuota(commands.MarshalMonCommand(pa.conn, m))
+}
@@ -0,0 +1,62 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package pool
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func TestParseQuota(t *testing.T) {
+	r := commands.NewResponse([]byte(`{
+  "pool_name": "rbd",
+  "pool_id": 2,
+  "quota_max_objects": 100,
+  "quota_max_bytes": 1048576,
+  "current_num_objects": 5,
+  "current_num_bytes": 4096
+}`), "", nil)
+	q, err := parseQuota(r)
+	require.NoError(t, err)
+	assert.Equal(t, "rbd", q.PoolName)
+	assert.EqualValues(t, 2, q.PoolID)
+	assert.EqualValues(t, 100, q.MaxObjects)
+	assert.EqualValues(t, 1048576, q.MaxBytes)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	q, err = parseQuota(r)
+	assert.Error(t, err)
+	assert.Nil(t, q)
+}
+
+func TestQuota(t *testing.T) {
+	pa := getAdmin(t)
+	name := createPool(t, pa)
+
+	q, err := pa.GetQuota(name)
+	require.NoError(t, err)
+	assert.Equal(t, name, q.PoolName)
+	assert.EqualValues(t, 0, q.MaxObjects)
+	assert.EqualValues(t, 0, q.MaxBytes)
+
+	err = pa.SetQuota(name, QuotaMaxObjects, 1000)
+	require.NoError(t, err)
+	err = pa.SetQuota(name, QuotaMaxBytes, 1<<30)
+	require.NoError(t, err)
+	q, err = pa.GetQuota(name)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1000, q.MaxObjects)
+	assert.EqualValues(t, 1<<30, q.MaxBytes)
+
+	err = pa.SetQuota(name, QuotaMaxObjects, 0)
+	require.NoError(t, err)
+	q, err = pa.GetQuota(name)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, q.MaxObjects)
+}
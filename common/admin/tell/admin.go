@@ -0,0 +1,32 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	ccom "github.com/ceph/go-ceph/common/commands"
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// Admin is used to send tell commands to a single ceph daemon.
+type Admin struct {
+	send func(v interface{}) commands.Response
+}
+
+// NewFromOsd creates a new management object that sends tell commands to
+// the OSD with the given ID. The connection can be rados.Conn or any type
+// implementing the OsdCommander interface.
+func NewFromOsd(conn ccom.OsdCommander, osd int) *Admin {
+	return &Admin{func(v interface{}) commands.Response {
+		return commands.MarshalOsdCommand(conn, osd, v)
+	}}
+}
+
+// NewFromMds creates a new management object that sends tell commands to
+// the MDS selected by mdsSpec, like "a" or "0". The connection can be
+// cephfs.MountInfo or any type implementing the MdsCommander interface.
+func NewFromMds(conn ccom.MdsCommander, mdsSpec string) *Admin {
+	return &Admin{func(v interface{}) commands.Response {
+		return commands.MarshalMdsCommand(conn, mdsSpec, v)
+	}}
+}
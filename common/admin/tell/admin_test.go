@@ -0,0 +1,47 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/admintest"
+)
+
+var radosConnector = admintest.NewConnector()
+
+func getOsdAdmin(t *testing.T) *Admin {
+	return NewFromOsd(radosConnector.GetConn(t), 0)
+}
+
+type recordingMdsCommander struct {
+	mdsSpec string
+	args    map[string]string
+}
+
+func (r *recordingMdsCommander) MdsCommand(mdsSpec string, buf [][]byte) ([]byte, string, error) {
+	r.mdsSpec = mdsSpec
+	r.args = map[string]string{}
+	if err := json.Unmarshal(buf[0], &r.args); err != nil {
+		return nil, "", err
+	}
+	return []byte(`{"mds":{"request":7}}`), "", nil
+}
+
+func TestNewFromMds(t *testing.T) {
+	r := &recordingMdsCommander{}
+	ta := NewFromMds(r, "a")
+	d, err := ta.PerfDump()
+	require.NoError(t, err)
+	assert.Equal(t, "a", r.mdsSpec)
+	assert.Equal(t, map[string]string{
+		"prefix": "perf dump",
+		"format": "json",
+	}, r.args)
+	assert.EqualValues(t, 7, d["mds"]["request"].Value)
+}
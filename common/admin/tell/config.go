@@ -0,0 +1,58 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// ConfigDiff maps the names of the options of a daemon that differ from
+// their defaults to the values of the options by source, like "default",
+// "file" or "mon". The "final" source holds the value in effect.
+type ConfigDiff map[string]map[string]string
+
+func parseConfigShow(res commands.Response) (map[string]string, error) {
+	c := map[string]string{}
+	if err := res.NoStatus().Unmarshal(&c).End(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ConfigShow returns the values of all the options of the running daemon.
+//
+// Similar To:
+//
+//	ceph tell <daemon> config show
+func (ta *Admin) ConfigShow() (map[string]string, error) {
+	m := map[string]string{
+		"prefix": "config show",
+		"format": "json",
+	}
+	return parseConfigShow(ta.send(m))
+}
+
+func parseConfigDiff(res commands.Response) (ConfigDiff, error) {
+	d := struct {
+		Diff ConfigDiff `json:"diff"`
+	}{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	return d.Diff, nil
+}
+
+// ConfigDiff returns the options of the running daemon that differ from
+// their defaults.
+//
+// Similar To:
+//
+//	ceph tell <daemon> config diff
+func (ta *Admin) ConfigDiff() (ConfigDiff, error) {
+	m := map[string]string{
+		"prefix": "config diff",
+		"format": "json",
+	}
+	return parseConfigDiff(ta.send(m))
+}
@@ -0,0 +1,72 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var configDiffJSON = `
+{
+  "diff": {
+    "admin_socket": {
+      "default": "$run_dir/$cluster-$name.$pid.$cctid.asok",
+      "final": "/var/run/ceph/ceph-osd.0.asok"
+    },
+    "osd_memory_target": {
+      "default": "4294967296",
+      "mon": "2147483648",
+      "final": "2147483648"
+    }
+  }
+}
+`
+
+func TestParseConfigShow(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"name":"osd.0","osd_memory_target":"2147483648"}`), "", nil)
+	c, err := parseConfigShow(r)
+	require.NoError(t, err)
+	assert.Equal(t, "osd.0", c["name"])
+	assert.Equal(t, "2147483648", c["osd_memory_target"])
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	c, err = parseConfigShow(r)
+	assert.Error(t, err)
+	assert.Nil(t, c)
+}
+
+func TestParseConfigDiff(t *testing.T) {
+	r := commands.NewResponse([]byte(configDiffJSON), "", nil)
+	d, err := parseConfigDiff(r)
+	require.NoError(t, err)
+	require.Len(t, d, 2)
+	assert.Equal(t, "2147483648", d["osd_memory_target"]["mon"])
+	assert.Equal(t, "2147483648", d["osd_memory_target"]["final"])
+	assert.Equal(t, "/var/run/ceph/ceph-osd.0.asok", d["admin_socket"]["final"])
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	d, err = parseConfigDiff(r)
+	assert.Error(t, err)
+	assert.Nil(t, d)
+}
+
+func TestConfig(t *testing.T) {
+	ta := getOsdAdmin(t)
+
+	c, err := ta.ConfigShow()
+	require.NoError(t, err)
+	assert.Equal(t, "osd.0", c["name"])
+
+	d, err := ta.ConfigDiff()
+	require.NoError(t, err)
+	for name, sources := range d {
+		assert.Contains(t, sources, "final", name)
+	}
+}
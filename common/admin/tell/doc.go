@@ -0,0 +1,6 @@
+/*
+Package tell from common/admin contains a set of APIs used to send tell
+commands to individual Ceph daemons, like the OSDs and MDSs, and to parse
+their responses into typed values.
+*/
+package tell
@@ -0,0 +1,35 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+func parseHeapStats(res commands.Response) (string, error) {
+	if err := res.End(); err != nil {
+		return "", err
+	}
+	// depending on the daemon and its version the statistics are returned
+	// as the body or as the status
+	if len(res.Body()) != 0 {
+		return string(res.Body()), nil
+	}
+	return res.Status(), nil
+}
+
+// HeapStats returns the statistics of the tcmalloc heap of the daemon as
+// formatted by tcmalloc. An error is returned if the daemon does not use
+// tcmalloc.
+//
+// Similar To:
+//
+//	ceph tell <daemon> heap stats
+func (ta *Admin) HeapStats() (string, error) {
+	m := map[string]string{
+		"prefix":  "heap",
+		"heapcmd": "stats",
+	}
+	return parseHeapStats(ta.send(m))
+}
@@ -0,0 +1,45 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var heapStatsText = `osd.0 tcmalloc heap stats:------------------------------------------------
+MALLOC:       24862720 (   23.7 MiB) Bytes in use by application
+MALLOC: +            0 (    0.0 MiB) Bytes in page heap freelist
+`
+
+func TestParseHeapStats(t *testing.T) {
+	r := commands.NewResponse([]byte(heapStatsText), "", nil)
+	s, err := parseHeapStats(r)
+	require.NoError(t, err)
+	assert.Equal(t, heapStatsText, s)
+
+	r = commands.NewResponse(nil, heapStatsText, nil)
+	s, err = parseHeapStats(r)
+	require.NoError(t, err)
+	assert.Equal(t, heapStatsText, s)
+
+	r = commands.NewResponse(nil, "could not issue heap profiler command -- not using tcmalloc!",
+		errors.New("foo"))
+	_, err = parseHeapStats(r)
+	assert.Error(t, err)
+}
+
+func TestHeapStats(t *testing.T) {
+	ta := getOsdAdmin(t)
+	s, err := ta.HeapStats()
+	if err != nil {
+		t.Skipf("heap stats not available: %v", err)
+	}
+	assert.Contains(t, s, "MALLOC")
+}
@@ -0,0 +1,88 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// OpEvent is an event in the life of an operation, like "initiated" or
+// "done".
+type OpEvent struct {
+	Event    string  `json:"event"`
+	Time     string  `json:"time"`
+	Duration float64 `json:"duration"`
+}
+
+// OpTypeData contains the details of an operation that are common to the
+// operations of all daemon types.
+type OpTypeData struct {
+	FlagPoint string    `json:"flag_point"`
+	Events    []OpEvent `json:"events"`
+}
+
+// Op is an operation tracked by a daemon. Age and Duration are in seconds.
+type Op struct {
+	Description string     `json:"description"`
+	InitiatedAt string     `json:"initiated_at"`
+	Age         float64    `json:"age"`
+	Duration    float64    `json:"duration"`
+	TypeData    OpTypeData `json:"type_data"`
+}
+
+// OpsInFlight contains the operations currently processed by a daemon.
+type OpsInFlight struct {
+	NumOps int  `json:"num_ops"`
+	Ops    []Op `json:"ops"`
+}
+
+// HistoricOps contains the recently completed operations of a daemon. Size
+// and Duration, in seconds, are the limits of the history.
+type HistoricOps struct {
+	Size     int     `json:"size"`
+	Duration float64 `json:"duration"`
+	Ops      []Op    `json:"ops"`
+}
+
+func parseOpsInFlight(res commands.Response) (*OpsInFlight, error) {
+	o := &OpsInFlight{}
+	if err := res.NoStatus().Unmarshal(o).End(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// DumpOpsInFlight returns the operations currently processed by the daemon.
+//
+// Similar To:
+//
+//	ceph tell <daemon> dump_ops_in_flight
+func (ta *Admin) DumpOpsInFlight() (*OpsInFlight, error) {
+	m := map[string]string{
+		"prefix": "dump_ops_in_flight",
+		"format": "json",
+	}
+	return parseOpsInFlight(ta.send(m))
+}
+
+func parseHistoricOps(res commands.Response) (*HistoricOps, error) {
+	o := &HistoricOps{}
+	if err := res.NoStatus().Unmarshal(o).End(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// DumpHistoricOps returns the recently completed operations of the daemon.
+//
+// Similar To:
+//
+//	ceph tell <daemon> dump_historic_ops
+func (ta *Admin) DumpHistoricOps() (*HistoricOps, error) {
+	m := map[string]string{
+		"prefix": "dump_historic_ops",
+		"format": "json",
+	}
+	return parseHistoricOps(ta.send(m))
+}
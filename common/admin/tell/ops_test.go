@@ -0,0 +1,89 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var opJSON = `
+{
+  "description": "osd_op(client.4123.0:17 2.7 2:e5b1b9b7:::rbd_header.1018a:head [watch ping cookie 94016864] snapc 0=[] ondisk+write+known_if_redirected e21)",
+  "initiated_at": "2023-08-01T10:04:17.386367+0000",
+  "age": 0.004218,
+  "duration": 0.004231,
+  "type_data": {
+    "flag_point": "commit sent; apply or cleanup",
+    "client_info": {
+      "client": "client.4123",
+      "client_addr": "10.0.0.7:0/3710147553",
+      "tid": 17
+    },
+    "events": [
+      {
+        "event": "initiated",
+        "time": "2023-08-01T10:04:17.386367+0000",
+        "duration": 0
+      },
+      {
+        "event": "done",
+        "time": "2023-08-01T10:04:17.390598+0000",
+        "duration": 0.004231
+      }
+    ]
+  }
+}
+`
+
+func TestParseOpsInFlight(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"ops":[`+opJSON+`],"num_ops":1}`), "", nil)
+	o, err := parseOpsInFlight(r)
+	require.NoError(t, err)
+	assert.Equal(t, 1, o.NumOps)
+	require.Len(t, o.Ops, 1)
+	op := o.Ops[0]
+	assert.Contains(t, op.Description, "osd_op(client.4123.0:17")
+	assert.Equal(t, "2023-08-01T10:04:17.386367+0000", op.InitiatedAt)
+	assert.InDelta(t, 0.004231, op.Duration, 1e-9)
+	assert.Equal(t, "commit sent; apply or cleanup", op.TypeData.FlagPoint)
+	require.Len(t, op.TypeData.Events, 2)
+	assert.Equal(t, "done", op.TypeData.Events[1].Event)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	o, err = parseOpsInFlight(r)
+	assert.Error(t, err)
+	assert.Nil(t, o)
+}
+
+func TestParseHistoricOps(t *testing.T) {
+	r := commands.NewResponse([]byte(`{"size":20,"duration":600,"ops":[`+opJSON+`]}`), "", nil)
+	o, err := parseHistoricOps(r)
+	require.NoError(t, err)
+	assert.Equal(t, 20, o.Size)
+	assert.EqualValues(t, 600, o.Duration)
+	assert.Len(t, o.Ops, 1)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	o, err = parseHistoricOps(r)
+	assert.Error(t, err)
+	assert.Nil(t, o)
+}
+
+func TestOps(t *testing.T) {
+	ta := getOsdAdmin(t)
+
+	o, err := ta.DumpOpsInFlight()
+	require.NoError(t, err)
+	assert.Equal(t, o.NumOps, len(o.Ops))
+
+	h, err := ta.DumpHistoricOps()
+	require.NoError(t, err)
+	assert.Greater(t, h.Size, 0)
+}
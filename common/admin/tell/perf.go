@@ -0,0 +1,131 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+// PerfCounterType is the bitmask describing the type of a performance
+// counter.
+type PerfCounterType uint8
+
+const (
+	// PerfCounterTime marks a counter whose values are durations in seconds.
+	PerfCounterTime = PerfCounterType(0x1)
+	// PerfCounterU64 marks a counter whose values are integers.
+	PerfCounterU64 = PerfCounterType(0x2)
+	// PerfCounterLongRunAvg marks a counter that is an average, with a count
+	// and a sum, rather than a single value.
+	PerfCounterLongRunAvg = PerfCounterType(0x4)
+	// PerfCounterCounter marks a counter that only ever increases, as
+	// opposed to a gauge.
+	PerfCounterCounter = PerfCounterType(0x8)
+	// PerfCounterHistogram marks a counter that is a histogram.
+	PerfCounterHistogram = PerfCounterType(0x10)
+)
+
+// Has returns true if all the bits of t are set in the type.
+func (pt PerfCounterType) Has(t PerfCounterType) bool {
+	return pt&t == t
+}
+
+// PerfCounterSchema describes a performance counter.
+type PerfCounterSchema struct {
+	Type        PerfCounterType `json:"type"`
+	MetricType  string          `json:"metric_type"`
+	ValueType   string          `json:"value_type"`
+	Description string          `json:"description"`
+	Nick        string          `json:"nick"`
+	Priority    int             `json:"priority"`
+	Units       string          `json:"units"`
+}
+
+// PerfSchema maps the names of the loggers of a daemon, like "osd", to the
+// schemas of their performance counters by name.
+type PerfSchema map[string]map[string]PerfCounterSchema
+
+// PerfCounter is the value of a performance counter. Value is set for the
+// counters holding a single value. AvgCount and Sum, as well as AvgTime for
+// the counters of type PerfCounterTime, are set for the counters of type
+// PerfCounterLongRunAvg.
+type PerfCounter struct {
+	Value    float64 `json:"-"`
+	AvgCount uint64  `json:"avgcount"`
+	Sum      float64 `json:"sum"`
+	AvgTime  float64 `json:"avgtime"`
+}
+
+// UnmarshalJSON implements the json Unmarshaler interface.
+func (pc *PerfCounter) UnmarshalJSON(b []byte) error {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		*pc = PerfCounter{}
+		return json.Unmarshal(b, &pc.Value)
+	}
+	type avg PerfCounter
+	a := avg{}
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*pc = PerfCounter(a)
+	return nil
+}
+
+// Average returns the average of a counter of type PerfCounterLongRunAvg,
+// or zero if nothing was counted yet.
+func (pc PerfCounter) Average() float64 {
+	if pc.AvgCount == 0 {
+		return 0
+	}
+	return pc.Sum / float64(pc.AvgCount)
+}
+
+// PerfDump maps the names of the loggers of a daemon, like "osd", to the
+// values of their performance counters by name.
+type PerfDump map[string]map[string]PerfCounter
+
+func parsePerfDump(res commands.Response) (PerfDump, error) {
+	d := PerfDump{}
+	if err := res.NoStatus().Unmarshal(&d).End(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// PerfDump returns the values of the performance counters of the daemon.
+//
+// Similar To:
+//
+//	ceph tell <daemon> perf dump
+func (ta *Admin) PerfDump() (PerfDump, error) {
+	m := map[string]string{
+		"prefix": "perf dump",
+		"format": "json",
+	}
+	return parsePerfDump(ta.send(m))
+}
+
+func parsePerfSchema(res commands.Response) (PerfSchema, error) {
+	s := PerfSchema{}
+	if err := res.NoStatus().Unmarshal(&s).End(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// PerfSchema returns the schemas of the performance counters of the daemon.
+//
+// Similar To:
+//
+//	ceph tell <daemon> perf schema
+func (ta *Admin) PerfSchema() (PerfSchema, error) {
+	m := map[string]string{
+		"prefix": "perf schema",
+		"format": "json",
+	}
+	return parsePerfSchema(ta.send(m))
+}
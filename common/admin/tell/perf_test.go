@@ -0,0 +1,148 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package tell
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/commands"
+)
+
+var perfDumpJSON = `
+{
+  "osd": {
+    "op_wip": 0,
+    "op": 128,
+    "op_in_bytes": 4194304,
+    "op_latency": {
+      "avgcount": 128,
+      "sum": 0.640000000,
+      "avgtime": 0.005000000
+    },
+    "op_before_queue_op_lat": {
+      "avgcount": 0,
+      "sum": 0.000000000,
+      "avgtime": 0.000000000
+    },
+    "op_rw_in_bytes": 0,
+    "osd_tier_flush_lat": 0.000000000,
+    "numpg": 33,
+    "stat_bytes": 21474836480
+  },
+  "throttle-osd_client_bytes": {
+    "val": 0,
+    "max": 524288000,
+    "get_sum": 4194304,
+    "wait": {
+      "avgcount": 0,
+      "sum": 0.000000000,
+      "avgtime": 0.000000000
+    }
+  }
+}
+`
+
+var perfSchemaJSON = `
+{
+  "osd": {
+    "op": {
+      "type": 10,
+      "metric_type": "counter",
+      "value_type": "integer",
+      "description": "Client operations",
+      "nick": "ops",
+      "priority": 10,
+      "units": "none"
+    },
+    "op_latency": {
+      "type": 5,
+      "metric_type": "gauge",
+      "value_type": "real-integer-pair",
+      "description": "Latency of client operations (including queue time)",
+      "nick": "l",
+      "priority": 9,
+      "units": "none"
+    },
+    "numpg": {
+      "type": 2,
+      "metric_type": "gauge",
+      "value_type": "integer",
+      "description": "Placement groups",
+      "nick": "pgs",
+      "priority": 10,
+      "units": "none"
+    }
+  }
+}
+`
+
+func TestParsePerfDump(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(perfDumpJSON), "", nil)
+		d, err := parsePerfDump(r)
+		require.NoError(t, err)
+		require.Contains(t, d, "osd")
+		osd := d["osd"]
+		assert.EqualValues(t, 128, osd["op"].Value)
+		assert.EqualValues(t, 21474836480, osd["stat_bytes"].Value)
+		assert.EqualValues(t, 0, osd["osd_tier_flush_lat"].Value)
+		lat := osd["op_latency"]
+		assert.EqualValues(t, 0, lat.Value)
+		assert.EqualValues(t, 128, lat.AvgCount)
+		assert.InDelta(t, 0.64, lat.Sum, 1e-9)
+		assert.InDelta(t, 0.005, lat.AvgTime, 1e-9)
+		assert.InDelta(t, 0.005, lat.Average(), 1e-9)
+		assert.EqualValues(t, 0, osd["op_before_queue_op_lat"].Average())
+		assert.EqualValues(t, 524288000, d["throttle-osd_client_bytes"]["max"].Value)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		r := commands.NewResponse([]byte(`{"osd":{"op":"many"}}`), "", nil)
+		_, err := parsePerfDump(r)
+		assert.Error(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		r := commands.NewResponse(nil, "", errors.New("foo"))
+		d, err := parsePerfDump(r)
+		assert.Error(t, err)
+		assert.Nil(t, d)
+	})
+}
+
+func TestParsePerfSchema(t *testing.T) {
+	r := commands.NewResponse([]byte(perfSchemaJSON), "", nil)
+	s, err := parsePerfSchema(r)
+	require.NoError(t, err)
+	op := s["osd"]["op"]
+	assert.Equal(t, PerfCounterU64|PerfCounterCounter, op.Type)
+	assert.True(t, op.Type.Has(PerfCounterCounter))
+	assert.False(t, op.Type.Has(PerfCounterLongRunAvg))
+	assert.Equal(t, "counter", op.MetricType)
+	assert.Equal(t, "ops", op.Nick)
+	lat := s["osd"]["op_latency"]
+	assert.True(t, lat.Type.Has(PerfCounterTime|PerfCounterLongRunAvg))
+	assert.Equal(t, "real-integer-pair", lat.ValueType)
+
+	r = commands.NewResponse(nil, "", errors.New("foo"))
+	s, err = parsePerfSchema(r)
+	assert.Error(t, err)
+	assert.Nil(t, s)
+}
+
+func TestPerf(t *testing.T) {
+	ta := getOsdAdmin(t)
+
+	s, err := ta.PerfSchema()
+	require.NoError(t, err)
+	require.Contains(t, s, "osd")
+	assert.True(t, s["osd"]["op_latency"].Type.Has(PerfCounterLongRunAvg))
+
+	d, err := ta.PerfDump()
+	require.NoError(t, err)
+	require.Contains(t, d, "osd")
+	assert.Greater(t, d["osd"]["numpg"].Value, float64(0))
+}
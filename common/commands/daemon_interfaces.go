@@ -0,0 +1,29 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package commands
+
+// OsdCommander is an interface for the API needed to execute JSON formatted
+// commands on a ceph OSD.
+type OsdCommander interface {
+	OsdCommand(osd int, buf [][]byte) ([]byte, string, error)
+}
+
+// MdsCommander is an interface for the API needed to execute JSON formatted
+// commands on a ceph MDS.
+type MdsCommander interface {
+	MdsCommand(mdsSpec string, buf [][]byte) ([]byte, string, error)
+}
+
+// PGCommander is an interface for the API needed to execute JSON formatted
+// commands on the primary OSD of a ceph PG.
+type PGCommander interface {
+	PGCommand(pgid []byte, buf [][]byte) ([]byte, string, error)
+}
+
+// RadosPGCommander provides an interface for APIs needed to execute JSON
+// formatted commands on the Ceph cluster, including commands on PGs.
+type RadosPGCommander interface {
+	RadosCommander
+	PGCommander
+}
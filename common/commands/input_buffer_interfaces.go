@@ -0,0 +1,18 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package commands
+
+// MonInputBufferCommander is an interface for the API needed to execute JSON
+// formatted commands, that take an input buffer, on the ceph mon(s).
+type MonInputBufferCommander interface {
+	MonCommandWithInputBuffer(buf, inputBuffer []byte) ([]byte, string, error)
+}
+
+// RadosInputBufferCommander provides an interface for APIs needed to execute
+// JSON formatted commands on the Ceph cluster, including mon commands that
+// take an input buffer.
+type RadosInputBufferCommander interface {
+	RadosCommander
+	MonInputBufferCommander
+}
@@ -0,0 +1,10 @@
+/*
+Package observer provides the types used to observe the I/O operations of the
+go-ceph packages, for example to collect metrics or tracing information.
+
+An Observer can be set on a rados.IOContext, an rbd.Image or a cephfs.File.
+Once set, it is informed about every I/O operation performed on the object
+after the operation completed. When no Observer is set the operations are
+not timed and no Operation values are created.
+*/
+package observer
@@ -0,0 +1,150 @@
+package observer_test
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// histogramKey identifies the series of a histogram.
+type histogramKey struct {
+	name   string
+	failed bool
+}
+
+// histogram is a cumulative histogram of operation durations, in the style
+// of a Prometheus histogram.
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+	bytes  int64
+}
+
+// PrometheusObserver is an example observer.Observer that collects the
+// durations of the observed operations in histograms, per operation name and
+// outcome, and writes them in the Prometheus text exposition format.
+type PrometheusObserver struct {
+	mutex   sync.Mutex
+	bounds  []float64
+	series  map[histogramKey]*histogram
+	metrics string
+}
+
+// NewPrometheusObserver returns a PrometheusObserver whose histograms use the
+// given upper bounds, in seconds, for their buckets.
+func NewPrometheusObserver(metrics string, bounds ...float64) *PrometheusObserver {
+	return &PrometheusObserver{
+		bounds:  bounds,
+		series:  map[histogramKey]*histogram{},
+		metrics: metrics,
+	}
+}
+
+// ObserveOperation implements the observer.Observer interface.
+func (p *PrometheusObserver) ObserveOperation(op observer.Operation) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	key := histogramKey{name: op.Name, failed: op.Err != nil}
+	h, ok := p.series[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(p.bounds))}
+		p.series[key] = h
+	}
+	seconds := op.Duration.Seconds()
+	for i, bound := range p.bounds {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+	h.bytes += op.Bytes
+}
+
+// WriteMetrics writes the histograms to w in the Prometheus text format.
+func (p *PrometheusObserver) WriteMetrics(w io.Writer) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	keys := make([]histogramKey, 0, len(p.series))
+	for key := range p.series {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return !keys[i].failed && keys[j].failed
+	})
+
+	fmt.Fprintf(w, "# TYPE %s_duration_seconds histogram\n", p.metrics)
+	for _, key := range keys {
+		h := p.series[key]
+		labels := fmt.Sprintf("op=%q,failed=\"%t\"", key.name, key.failed)
+		for i, bound := range p.bounds {
+			fmt.Fprintf(w, "%s_duration_seconds_bucket{%s,le=\"%g\"} %d\n",
+				p.metrics, labels, bound, h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n",
+			p.metrics, labels, h.count)
+		fmt.Fprintf(w, "%s_duration_seconds_sum{%s} %g\n", p.metrics, labels, h.sum)
+		fmt.Fprintf(w, "%s_duration_seconds_count{%s} %d\n", p.metrics, labels, h.count)
+	}
+	fmt.Fprintf(w, "# TYPE %s_bytes_total counter\n", p.metrics)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s_bytes_total{op=%q,failed=\"%t\"} %d\n",
+			p.metrics, key.name, key.failed, p.series[key].bytes)
+	}
+}
+
+func Example_prometheus() {
+	p := NewPrometheusObserver("goceph", 0.001, 0.01, 0.1)
+
+	// In a real application the observer is set on an I/O context, image
+	// or file, for example with ioctx.SetObserver(p), and the metrics are
+	// written by the handler of the metrics endpoint.
+	p.ObserveOperation(observer.Operation{
+		Name:     "rados.IOContext.Read",
+		Pool:     "data",
+		Object:   "obj1",
+		Bytes:    4096,
+		Duration: 500 * time.Microsecond,
+	})
+	p.ObserveOperation(observer.Operation{
+		Name:     "rados.IOContext.Read",
+		Pool:     "data",
+		Object:   "obj2",
+		Bytes:    4096,
+		Duration: 5 * time.Millisecond,
+	})
+	p.ObserveOperation(observer.Operation{
+		Name:     "rados.IOContext.Read",
+		Pool:     "data",
+		Object:   "obj3",
+		Duration: 250 * time.Millisecond,
+		Err:      fmt.Errorf("timed out"),
+	})
+	p.WriteMetrics(os.Stdout)
+	// Output:
+	// # TYPE goceph_duration_seconds histogram
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="false",le="0.001"} 1
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="false",le="0.01"} 2
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="false",le="0.1"} 2
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="false",le="+Inf"} 2
+	// goceph_duration_seconds_sum{op="rados.IOContext.Read",failed="false"} 0.0055
+	// goceph_duration_seconds_count{op="rados.IOContext.Read",failed="false"} 2
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="true",le="0.001"} 0
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="true",le="0.01"} 0
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="true",le="0.1"} 0
+	// goceph_duration_seconds_bucket{op="rados.IOContext.Read",failed="true",le="+Inf"} 1
+	// goceph_duration_seconds_sum{op="rados.IOContext.Read",failed="true"} 0.25
+	// goceph_duration_seconds_count{op="rados.IOContext.Read",failed="true"} 1
+	// # TYPE goceph_bytes_total counter
+	// goceph_bytes_total{op="rados.IOContext.Read",failed="false"} 8192
+	// goceph_bytes_total{op="rados.IOContext.Read",failed="true"} 0
+}
@@ -0,0 +1,39 @@
+package observer
+
+import (
+	"time"
+)
+
+// Operation describes a completed I/O operation.
+type Operation struct {
+	// Name is the name of the operation in the form package.Type.Method,
+	// for example "rados.IOContext.Read".
+	Name string
+	// Pool is the name of the pool the operation was performed in. It is
+	// empty for cephfs operations.
+	Pool string
+	// Namespace is the rados namespace the operation was performed in.
+	Namespace string
+	// Object is the key of the rados object the operation was performed on.
+	Object string
+	// Image is the name of the rbd image the operation was performed on.
+	Image string
+	// Path is the path of the cephfs file the operation was performed on.
+	Path string
+	// Bytes is the number of bytes read or written by the operation.
+	Bytes int64
+	// Duration is the time it took to perform the operation.
+	Duration time.Duration
+	// Err is the error returned by the operation, if any.
+	Err error
+}
+
+// Observer is the interface that needs to be implemented in order to
+// observe I/O operations.
+//
+// ObserveOperation is called synchronously, after the operation completed,
+// by the goroutine that performed the operation. Implementations must be
+// safe for concurrent use and should return quickly.
+type Observer interface {
+	ObserveOperation(op Operation)
+}
@@ -0,0 +1,23 @@
+package aio
+
+/*
+#include <stdint.h>
+
+extern void aioCompletionCallback(void*, uintptr_t);
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+// callback is the C function called by librados or librbd once an
+// asynchronous operation is complete. Its signature matches both
+// rados_callback_t and rbd_callback_t, with the argument being the index of
+// the Completion.
+var callback = unsafe.Pointer(C.aioCompletionCallback)
+
+//export aioCompletionCallback
+func aioCompletionCallback(_ unsafe.Pointer, index uintptr) {
+	complete(index)
+}
@@ -0,0 +1,219 @@
+// Package aio implements the state machine of the completions of
+// asynchronous operations, shared by the rados, rados/striper and rbd
+// packages. The C calls that create, query and release a completion are
+// specific to each library and are passed in as Ops.
+package aio
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/callbacks"
+)
+
+// completions tracks the completions of all in-flight async operations.
+var completions = callbacks.New()
+
+// Ops are the C calls of a library to manage its completions.
+type Ops struct {
+	// Create creates a C completion that calls cb with arg once the
+	// operation is complete, and returns the completion and the return code
+	// of the C call.
+	Create func(cb unsafe.Pointer, arg uintptr) (unsafe.Pointer, int)
+	// Release releases a C completion.
+	Release func(c unsafe.Pointer)
+	// ReturnValue returns the return value of the completed operation.
+	ReturnValue func(c unsafe.Pointer) int
+	// Version returns the version of the object the operation was performed
+	// on. It is optional.
+	Version func(c unsafe.Pointer) uint64
+	// Error converts a negative return code of the library to an error, and
+	// returns nil otherwise.
+	Error func(ret int) error
+}
+
+// Finisher is called once the asynchronous operation is complete. It is
+// passed the return value of the operation and is expected to convert any C
+// level outputs to Go values and return the error for the operation.
+type Finisher func(ret int) error
+
+// Completion tracks an asynchronous operation from its start until it is
+// complete. It releases all of its C resources on its own when the
+// operation is complete.
+type Completion struct {
+	ops     *Ops
+	c       unsafe.Pointer
+	cbIndex uintptr
+	finish  Finisher
+	// free releases C memory tied to the operation. It is called when the
+	// operation is complete or could not be started.
+	free func()
+
+	mutex    sync.Mutex
+	done     chan struct{}
+	callback func()
+	// abandoned is set if the caller stopped waiting for the operation. The
+	// finisher of an abandoned operation is not called, so that its outputs
+	// are not updated after the caller may have reused them.
+	abandoned bool
+
+	// results:
+	ret     int
+	version uint64
+	err     error
+}
+
+// New returns a Completion for an operation that is about to be started. If
+// finish is nil the error of the operation is derived from its return
+// value.
+func New(ops *Ops, finish Finisher, free func()) (*Completion, error) {
+	comp := &Completion{
+		ops:    ops,
+		finish: finish,
+		free:   free,
+		done:   make(chan struct{}),
+	}
+	comp.cbIndex = completions.Add(comp)
+	c, ret := ops.Create(callback, comp.cbIndex)
+	comp.c = c
+	if ret != 0 {
+		completions.Remove(comp.cbIndex)
+		comp.release()
+		return nil, ops.Error(ret)
+	}
+	return comp, nil
+}
+
+// Handle returns the C completion, to be passed to the C call that starts
+// the operation.
+func (comp *Completion) Handle() unsafe.Pointer {
+	return comp.c
+}
+
+func (comp *Completion) release() {
+	if comp.c != nil {
+		comp.ops.Release(comp.c)
+		comp.c = nil
+	}
+	if comp.free != nil {
+		comp.free()
+		comp.free = nil
+	}
+}
+
+// Submitted must be called with the return code of the C call that started
+// the asynchronous operation. If the operation could not be started the
+// resources of the Completion are freed and an error is returned.
+func (comp *Completion) Submitted(ret int) error {
+	if ret != 0 {
+		completions.Remove(comp.cbIndex)
+		comp.release()
+		return comp.ops.Error(ret)
+	}
+	return nil
+}
+
+func complete(index uintptr) {
+	v := completions.Lookup(index)
+	completions.Remove(index)
+	v.(*Completion).complete()
+}
+
+func (comp *Completion) complete() {
+	ret := comp.ops.ReturnValue(comp.c)
+
+	comp.mutex.Lock()
+	comp.ret = ret
+	if comp.ops.Version != nil {
+		comp.version = comp.ops.Version(comp.c)
+	}
+	if comp.finish != nil && !comp.abandoned {
+		comp.err = comp.finish(ret)
+	} else {
+		comp.err = comp.ops.Error(ret)
+	}
+	comp.release()
+	close(comp.done)
+	cb := comp.callback
+	comp.mutex.Unlock()
+	if cb != nil {
+		cb()
+	}
+}
+
+// Done returns a channel that is closed when the asynchronous operation is
+// complete.
+func (comp *Completion) Done() <-chan struct{} {
+	return comp.done
+}
+
+// IsComplete returns true if the asynchronous operation is complete.
+func (comp *Completion) IsComplete() bool {
+	select {
+	case <-comp.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Wait blocks until the asynchronous operation is complete and returns the
+// error, if any, of the operation.
+func (comp *Completion) Wait() error {
+	<-comp.done
+	return comp.err
+}
+
+// WaitContext blocks until the asynchronous operation is complete, and
+// returns its error, or until the context is done. In the latter case the
+// Completion is abandoned and ctx.Err() is returned. The operation
+// continues in the cluster but its finisher is not called, and the
+// resources of the Completion are released once it is complete.
+func (comp *Completion) WaitContext(ctx context.Context) error {
+	select {
+	case <-comp.done:
+		return comp.err
+	case <-ctx.Done():
+	}
+
+	comp.mutex.Lock()
+	defer comp.mutex.Unlock()
+	select {
+	case <-comp.done:
+		// the operation completed while the context was done
+		return comp.err
+	default:
+	}
+	comp.abandoned = true
+	return ctx.Err()
+}
+
+// Result returns the return value of the operation and its error. The
+// values are only valid once the operation is complete.
+func (comp *Completion) Result() (int, error) {
+	return comp.ret, comp.err
+}
+
+// Version returns the version of the object the operation was performed on.
+// The value is only valid once the operation is complete.
+func (comp *Completion) Version() uint64 {
+	return comp.version
+}
+
+// OnComplete registers a function to be called once the asynchronous
+// operation is complete. If the operation is already complete the function is
+// called immediately. Otherwise the function is called from a thread owned by
+// the library and should return quickly.
+func (comp *Completion) OnComplete(cb func()) {
+	comp.mutex.Lock()
+	select {
+	case <-comp.done:
+		comp.mutex.Unlock()
+		cb()
+		return
+	default:
+	}
+	comp.callback = cb
+	comp.mutex.Unlock()
+}
@@ -0,0 +1,147 @@
+package aio
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFake = errors.New("fake error")
+
+type fakeLib struct {
+	createRet int
+	ret       int
+	released  int
+}
+
+func (f *fakeLib) ops() *Ops {
+	return &Ops{
+		Create: func(cb unsafe.Pointer, arg uintptr) (unsafe.Pointer, int) {
+			if f.createRet != 0 {
+				return nil, f.createRet
+			}
+			return unsafe.Pointer(new(int)), 0
+		},
+		Release: func(c unsafe.Pointer) {
+			f.released++
+		},
+		ReturnValue: func(c unsafe.Pointer) int {
+			return f.ret
+		},
+		Version: func(c unsafe.Pointer) uint64 {
+			return 42
+		},
+		Error: func(ret int) error {
+			if ret < 0 {
+				return errFake
+			}
+			return nil
+		},
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	f := &fakeLib{ret: 5}
+	finished, freed, called := 0, 0, 0
+	comp, err := New(f.ops(),
+		func(ret int) error {
+			finished++
+			assert.Equal(t, 5, ret)
+			return nil
+		},
+		func() { freed++ })
+	require.NoError(t, err)
+	assert.NotNil(t, comp.Handle())
+	require.NoError(t, comp.Submitted(0))
+	comp.OnComplete(func() { called++ })
+	assert.False(t, comp.IsComplete())
+
+	complete(comp.cbIndex)
+	assert.True(t, comp.IsComplete())
+	assert.NoError(t, comp.Wait())
+	ret, err := comp.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, ret)
+	assert.EqualValues(t, 42, comp.Version())
+	assert.Equal(t, 1, finished)
+	assert.Equal(t, 1, freed)
+	assert.Equal(t, 1, f.released)
+	assert.Equal(t, 1, called)
+	assert.Nil(t, completions.Lookup(comp.cbIndex))
+
+	// registered after completion, called immediately
+	comp.OnComplete(func() { called++ })
+	assert.Equal(t, 2, called)
+}
+
+func TestCompletionError(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		f := &fakeLib{createRet: -1}
+		freed := 0
+		_, err := New(f.ops(), nil, func() { freed++ })
+		assert.Equal(t, errFake, err)
+		assert.Equal(t, 1, freed)
+		assert.Equal(t, 0, f.released)
+	})
+
+	t.Run("submit", func(t *testing.T) {
+		f := &fakeLib{}
+		freed := 0
+		comp, err := New(f.ops(), nil, func() { freed++ })
+		require.NoError(t, err)
+		assert.Equal(t, errFake, comp.Submitted(-1))
+		assert.Equal(t, 1, freed)
+		assert.Equal(t, 1, f.released)
+		assert.Nil(t, completions.Lookup(comp.cbIndex))
+	})
+
+	t.Run("operation", func(t *testing.T) {
+		f := &fakeLib{ret: -1}
+		comp, err := New(f.ops(), nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, comp.Submitted(0))
+		complete(comp.cbIndex)
+		assert.Equal(t, errFake, comp.Wait())
+	})
+}
+
+func TestCompletionWaitContext(t *testing.T) {
+	t.Run("complete", func(t *testing.T) {
+		f := &fakeLib{ret: -1}
+		comp, err := New(f.ops(), nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, comp.Submitted(0))
+		complete(comp.cbIndex)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// a complete operation is not abandoned
+		assert.Equal(t, errFake, comp.WaitContext(ctx))
+	})
+
+	t.Run("abandon", func(t *testing.T) {
+		f := &fakeLib{ret: 5}
+		finished, freed := 0, 0
+		comp, err := New(f.ops(),
+			func(ret int) error {
+				finished++
+				return nil
+			},
+			func() { freed++ })
+		require.NoError(t, err)
+		require.NoError(t, comp.Submitted(0))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Equal(t, context.Canceled, comp.WaitContext(ctx))
+		assert.Equal(t, 0, freed)
+
+		complete(comp.cbIndex)
+		assert.Equal(t, 0, finished)
+		assert.Equal(t, 1, freed)
+		assert.Equal(t, 1, f.released)
+	})
+}
@@ -0,0 +1,68 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package commands
+
+import (
+	"encoding/json"
+
+	ccom "github.com/ceph/go-ceph/common/commands"
+)
+
+// RawOsdCommand takes a byte buffer and sends it to the given OSD as a
+// command. The buffer is expected to contain preformatted JSON.
+func RawOsdCommand(m ccom.OsdCommander, osd int, buf []byte) Response {
+	if err := validate(m); err != nil {
+		return Response{err: err}
+	}
+	return NewResponse(m.OsdCommand(osd, [][]byte{buf}))
+}
+
+// MarshalOsdCommand takes an generic interface{} value, converts it to JSON
+// and sends the json to the given OSD as a command.
+func MarshalOsdCommand(m ccom.OsdCommander, osd int, v interface{}) Response {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return Response{err: err}
+	}
+	return RawOsdCommand(m, osd, b)
+}
+
+// RawMdsCommand takes a byte buffer and sends it to the MDS selected by
+// mdsSpec as a command. The buffer is expected to contain preformatted JSON.
+func RawMdsCommand(m ccom.MdsCommander, mdsSpec string, buf []byte) Response {
+	if err := validate(m); err != nil {
+		return Response{err: err}
+	}
+	return NewResponse(m.MdsCommand(mdsSpec, [][]byte{buf}))
+}
+
+// MarshalMdsCommand takes an generic interface{} value, converts it to JSON
+// and sends the json to the MDS selected by mdsSpec as a command.
+func MarshalMdsCommand(m ccom.MdsCommander, mdsSpec string, v interface{}) Response {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return Response{err: err}
+	}
+	return RawMdsCommand(m, mdsSpec, b)
+}
+
+// RawPGCommand takes a byte buffer and sends it to the primary OSD of the
+// given PG as a command. The buffer is expected to contain preformatted
+// JSON.
+func RawPGCommand(m ccom.PGCommander, pgid string, buf []byte) Response {
+	if err := validate(m); err != nil {
+		return Response{err: err}
+	}
+	return NewResponse(m.PGCommand([]byte(pgid), [][]byte{buf}))
+}
+
+// MarshalPGCommand takes an generic interface{} value, converts it to JSON
+// and sends the json to the primary OSD of the given PG as a command.
+func MarshalPGCommand(m ccom.PGCommander, pgid string, v interface{}) Response {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return Response{err: err}
+	}
+	return RawPGCommand(m, pgid, b)
+}
@@ -21,6 +21,7 @@ const (
 	deprecatedSuffix = "call is deprecated and will be removed in a future release"
 	missingPrefix    = "No handler found"
 	einval           = -22
+	enoent           = -2
 )
 
 type cephError interface {
@@ -89,6 +90,15 @@ func (r Response) End() error {
 	return nil
 }
 
+// NotFound returns true if the response contains an error with the error
+// code ENOENT, which ceph returns for missing items.
+func (r Response) NotFound() bool {
+	if ce, ok := r.err.(cephError); ok {
+		return ce.ErrorCode() == enoent
+	}
+	return false
+}
+
 // NoStatus asserts that the input response has no status value.
 func (r Response) NoStatus() Response {
 	if !r.Ok() {
@@ -138,6 +138,18 @@ func TestResponse(t *testing.T) {
 		}
 	})
 
+	t.Run("notFound", func(t *testing.T) {
+		assert.False(t, r1.NotFound())
+		assert.False(t, r2.NotFound())
+		rtemp := Response{
+			status: "key 'foo' doesn't exist",
+			err:    myCephError(-2),
+		}
+		assert.True(t, rtemp.NotFound())
+		rtemp.err = myCephError(-13)
+		assert.False(t, rtemp.NotFound())
+	})
+
 	t.Run("filterBodyPrefix", func(t *testing.T) {
 		rtemp := Response{
 			body: []byte("No way, no how"),
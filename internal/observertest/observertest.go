@@ -0,0 +1,39 @@
+// Package observertest provides helpers for testing the observer hooks of
+// the go-ceph packages.
+package observertest
+
+import (
+	"sync"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// Recorder is an observer.Observer that records the operations it is
+// informed about. It is safe for concurrent use.
+type Recorder struct {
+	mutex sync.Mutex
+	ops   []observer.Operation
+}
+
+// ObserveOperation records the operation.
+func (r *Recorder) ObserveOperation(op observer.Operation) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.ops = append(r.ops, op)
+}
+
+// Operations returns the recorded operations.
+func (r *Recorder) Operations() []observer.Operation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]observer.Operation(nil), r.ops...)
+}
+
+// Take returns the recorded operations and forgets them.
+func (r *Recorder) Take() []observer.Operation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	ops := r.ops
+	r.ops = nil
+	return ops
+}
@@ -0,0 +1,29 @@
+package observertest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+func TestRecorder(t *testing.T) {
+	var o observer.Observer = &Recorder{}
+	r := o.(*Recorder)
+	assert.Len(t, r.Operations(), 0)
+
+	o.ObserveOperation(observer.Operation{Name: "a"})
+	o.ObserveOperation(observer.Operation{Name: "b"})
+	ops := r.Operations()
+	assert.Len(t, ops, 2)
+	assert.Equal(t, "a", ops[0].Name)
+	// the copy is not affected by later operations
+	o.ObserveOperation(observer.Operation{Name: "c"})
+	assert.Len(t, ops, 2)
+
+	ops = r.Take()
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "c", ops[2].Name)
+	assert.Len(t, r.Take(), 0)
+}
@@ -0,0 +1,56 @@
+// Package radostypes contains the types of the rados package that do not
+// depend on librados, so that they can be shared with the cgo free
+// rados/api and rados/fake packages.
+package radostypes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Error represents an error condition returned from the Ceph RADOS APIs.
+type Error int
+
+// Error returns the error string for the Error type.
+func (e Error) Error() string {
+	return formatErrorCode("rados", int(e))
+}
+
+// ErrorCode returns the error code of the Error.
+func (e Error) ErrorCode() int {
+	return int(e)
+}
+
+// formatErrorCode returns the same string as errutil.FormatErrorCode without
+// using cgo. The errno descriptions of the syscall package are those of the
+// C library, apart from the case of the first letter.
+func formatErrorCode(source string, errValue int) string {
+	errno := errValue
+	if errno < 0 {
+		errno = -errno
+	}
+	s := syscall.Errno(errno).Error()
+	if strings.HasPrefix(s, "errno ") {
+		return fmt.Sprintf("%s: ret=%d", source, errValue)
+	}
+	r, n := utf8.DecodeRuneInString(s)
+	return fmt.Sprintf("%s: ret=%d, %c%s",
+		source, errValue, unicode.ToUpper(r), s[n:])
+}
+
+// ErrOperationIncomplete is returned from write op or read op steps for
+// which the operation has not been performed yet.
+var ErrOperationIncomplete = errors.New("Operation has not been performed yet")
+
+const (
+	// ErrNotFound indicates a missing resource.
+	ErrNotFound = Error(-int(syscall.ENOENT))
+	// ErrPermissionDenied indicates a permissions issue.
+	ErrPermissionDenied = Error(-int(syscall.EPERM))
+	// ErrObjectExists indicates that an exclusive object creation failed.
+	ErrObjectExists = Error(-int(syscall.EEXIST))
+)
@@ -0,0 +1,22 @@
+//go:build cgo
+// +build cgo
+
+package radostypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ceph/go-ceph/internal/errutil"
+)
+
+func TestFormatErrorCodeMatchesC(t *testing.T) {
+	// the errno descriptions match those of the C library, which is used
+	// by the errors of the other packages
+	for code := 1; code < 133; code++ {
+		assert.Equal(t,
+			errutil.FormatErrorCode("rados", -code),
+			formatErrorCode("rados", -code))
+	}
+}
@@ -0,0 +1,38 @@
+package radostypes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	assert.Equal(t, "rados: ret=-2, No such file or directory",
+		ErrNotFound.Error())
+	assert.Equal(t, -17, ErrObjectExists.ErrorCode())
+	assert.Equal(t, "rados: ret=345", Error(345).Error())
+}
+
+func TestOperationError(t *testing.T) {
+	oe := NewOperationError("read", fmt.Errorf("bad mojo %v", 77),
+		map[int]error{
+			1: fmt.Errorf("limit exceeded"),
+			3: fmt.Errorf("weirdness"),
+		})
+	assert.Error(t, oe)
+	estr := oe.Error()
+	assert.Contains(t, estr, "read operation error")
+	assert.Contains(t, estr, "op=bad mojo 77")
+	assert.Contains(t, estr, "Step#1=limit exceeded")
+	assert.Contains(t, estr, "Step#3=weirdness")
+
+	oe = NewOperationError("write", nil, map[int]error{
+		0: fmt.Errorf("unlucky"),
+	})
+	assert.Error(t, oe)
+	estr = oe.Error()
+	assert.Contains(t, estr, "write operation error")
+	assert.NotContains(t, estr, "op=")
+	assert.Contains(t, estr, "Step#0=unlucky")
+}
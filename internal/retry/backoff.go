@@ -0,0 +1,56 @@
+package retry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff configures the delays between the calls made by WithBackoff.
+type Backoff struct {
+	// Attempts is the maximum number of calls.
+	Attempts int
+	// Initial is the delay before the second call. The delay is doubled for
+	// every following call.
+	Initial time.Duration
+	// Max is the upper limit of the delay.
+	Max time.Duration
+}
+
+// BackoffFunc is called by WithBackoff with the number of the attempt,
+// starting at zero. It returns true if it should be called again. Like for
+// SizeFunc, errors or other results can be written to function closures of
+// the surrounding scope.
+type BackoffFunc func(attempt int) (again bool)
+
+// WithBackoff repeatingly calls a BackoffFunc until it returns false or the
+// maximum number of attempts has been reached. Between the calls it sleeps
+// for an exponentially growing delay. The delays are randomized between half
+// and the full delay, to spread out the retries of competing callers. If the
+// context is done while sleeping the context's error is returned.
+func WithBackoff(ctx context.Context, b Backoff, f BackoffFunc) error {
+	delay := b.Initial
+	for attempt := 0; attempt < b.Attempts; attempt++ {
+		if !f(attempt) || attempt == b.Attempts-1 {
+			break
+		}
+
+		d := delay / 2
+		if d > 0 {
+			d += time.Duration(rand.Int63n(int64(d) + 1))
+		}
+		t := time.NewTimer(d)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		delay *= 2
+		if delay > b.Max {
+			delay = b.Max
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBackoff(t *testing.T) {
+	b := Backoff{Attempts: 5, Initial: time.Millisecond, Max: 4 * time.Millisecond}
+
+	t.Run("success", func(t *testing.T) {
+		calls := 0
+		err := WithBackoff(context.Background(), b, func(attempt int) bool {
+			assert.Equal(t, calls, attempt)
+			calls++
+			return attempt < 2
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("exhausted", func(t *testing.T) {
+		calls := 0
+		err := WithBackoff(context.Background(), b, func(int) bool {
+			calls++
+			return true
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 5, calls)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := WithBackoff(ctx, b, func(int) bool {
+			calls++
+			cancel()
+			return true
+		})
+		assert.Equal(t, context.Canceled, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("delays", func(t *testing.T) {
+		slow := Backoff{Attempts: 4, Initial: 20 * time.Millisecond, Max: 40 * time.Millisecond}
+		start := time.Now()
+		err := WithBackoff(context.Background(), slow, func(int) bool {
+			return true
+		})
+		assert.NoError(t, err)
+		// at least half of 20ms + 40ms + 40ms
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+}
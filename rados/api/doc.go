@@ -0,0 +1,11 @@
+/*
+Package api contains the interfaces for the commonly used API of the I/O
+contexts of the rados package, along with the types, flags and errors they
+use.
+
+The package does not use cgo, so that code written against the interfaces,
+and the in-memory implementation of the rados/fake package, can be built and
+tested without the Ceph libraries. The types, flags and errors are the same
+as those of the rados package, which implements the interfaces.
+*/
+package api
@@ -0,0 +1,112 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package api
+
+import (
+	"time"
+)
+
+// ObjectIO is an interface for the API needed to read, write and list the
+// objects of a pool.
+type ObjectIO interface {
+	Read(oid string, data []byte, offset uint64) (int, error)
+	Write(oid string, data []byte, offset uint64) error
+	WriteFull(oid string, data []byte) error
+	Append(oid string, data []byte) error
+	Truncate(oid string, size uint64) error
+	Create(oid string, exclusive CreateOption) error
+	Delete(oid string) error
+	Stat(oid string) (ObjectStat, error)
+	ListObjects(listFn ObjectListFunc) error
+	SetNamespace(namespace string)
+	GetNamespace() (string, error)
+	GetLastVersion() (uint64, error)
+}
+
+// XattrIO is an interface for the API needed to manage the xattrs of
+// objects.
+type XattrIO interface {
+	GetXattr(oid, name string, data []byte) (int, error)
+	SetXattr(oid, name string, data []byte) error
+	ListXattrs(oid string) (map[string][]byte, error)
+	RmXattr(oid, name string) error
+}
+
+// OmapIO is an interface for the API needed to manage the omap of objects.
+type OmapIO interface {
+	SetOmap(oid string, pairs map[string][]byte) error
+	ListOmapValues(oid, startAfter, filterPrefix string, maxReturn int64, listFn OmapListFunc) error
+	GetOmapValues(oid, startAfter, filterPrefix string, maxReturn int64) (map[string][]byte, error)
+	GetAllOmapValues(oid, startAfter, filterPrefix string, iteratorSize int64) (map[string][]byte, error)
+	RmOmapKeys(oid string, keys []string) error
+	CleanOmap(oid string) error
+}
+
+// LockIO is an interface for the API needed to manage advisory locks on
+// objects.
+type LockIO interface {
+	LockExclusive(oid, name, cookie, desc string, duration time.Duration, flags *byte) (int, error)
+	LockShared(oid, name, cookie, tag, desc string, duration time.Duration, flags *byte) (int, error)
+	Unlock(oid, name, cookie string) (int, error)
+	ListLockers(oid, name string) (*LockInfo, error)
+	BreakLock(oid, name, client, cookie string) (int, error)
+}
+
+// OperationIO is an interface for the API needed to perform compound read
+// and write operations on objects.
+type OperationIO interface {
+	NewWriteOperation() WriteOperation
+	NewReadOperation() ReadOperation
+}
+
+// IOContextAPI is an interface for the commonly used API of the IOContext of
+// the rados package. Code that accepts an IOContextAPI rather than a
+// *rados.IOContext can be tested with an alternative implementation, like
+// the in-memory one of the rados/fake package.
+type IOContextAPI interface {
+	ObjectIO
+	XattrIO
+	OmapIO
+	LockIO
+	OperationIO
+}
+
+// WriteOperation is an interface for the commonly used API of the WriteOp
+// of the rados package, bound to the I/O context it was created by.
+type WriteOperation interface {
+	Create(exclusive CreateOption)
+	Remove()
+	Write(b []byte, offset uint64)
+	WriteFull(b []byte)
+	Append(b []byte)
+	Truncate(offset uint64)
+	SetXattr(name string, value []byte)
+	RmXattr(name string)
+	SetOmap(pairs map[string][]byte)
+	RmOmapKeys(keys []string)
+	CleanOmap()
+	AssertExists()
+	AssertVersion(ver uint64)
+	Operate(oid string, flags OperationFlags) error
+	Release()
+}
+
+// ReadOperation is an interface for the commonly used API of the ReadOp of
+// the rados package, bound to the I/O context it was created by.
+type ReadOperation interface {
+	AssertExists()
+	AssertVersion(ver uint64)
+	Read(offset uint64, buffer []byte) *ReadOpReadStep
+	Stat() *ReadOpStatStep
+	GetOmapValues(startAfter, filterPrefix string, maxReturn uint64) OmapIterator
+	Operate(oid string, flags OperationFlags) error
+	Release()
+}
+
+// OmapIterator is an interface for the API needed to iterate over the omap
+// key-value pairs returned by a read operation.
+type OmapIterator interface {
+	Next() (*OmapKeyValue, error)
+	More() bool
+}
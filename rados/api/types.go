@@ -0,0 +1,109 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package api
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/internal/radostypes"
+)
+
+// CreateOption is passed to Create and should be one of CreateExclusive or
+// CreateIdempotent.
+type CreateOption = radostypes.CreateOption
+
+const (
+	// CreateExclusive if used with Create and the object already exists,
+	// the function will return an error.
+	CreateExclusive = CreateOption(1)
+	// CreateIdempotent if used with Create and the object already exists,
+	// the function will not return an error.
+	CreateIdempotent = CreateOption(0)
+)
+
+// AllNamespaces is used to reset a selected namespace to all namespaces.
+// See the SetNamespace function.
+const AllNamespaces = "\001"
+
+// OperationFlags control the behavior of read and write operations.
+type OperationFlags = radostypes.OperationFlags
+
+// The values of the flags are those of librados.
+const (
+	// OperationNoFlag indicates no special behavior is requested.
+	OperationNoFlag = OperationFlags(0)
+	// OperationBalanceReads TODO
+	OperationBalanceReads = OperationFlags(1)
+	// OperationLocalizeReads TODO
+	OperationLocalizeReads = OperationFlags(2)
+	// OperationOrderReadsWrites TODO
+	OperationOrderReadsWrites = OperationFlags(4)
+	// OperationIgnoreCache TODO
+	OperationIgnoreCache = OperationFlags(8)
+	// OperationSkipRWLocks TODO
+	OperationSkipRWLocks = OperationFlags(16)
+	// OperationIgnoreOverlay TODO
+	OperationIgnoreOverlay = OperationFlags(32)
+	// OperationFullTry send request to a full cluster or pool, ops such as
+	// delete can succeed while other ops will return out-of-space errors.
+	OperationFullTry = OperationFlags(64)
+	// OperationFullForce TODO
+	OperationFullForce = OperationFlags(128)
+	// OperationIgnoreRedirect TODO
+	OperationIgnoreRedirect = OperationFlags(256)
+	// OperationOrderSnap TODO
+	OperationOrderSnap = OperationFlags(512)
+)
+
+// ObjectStat represents an object stat information
+type ObjectStat = radostypes.ObjectStat
+
+// LockInfo represents information on a current Ceph lock
+type LockInfo = radostypes.LockInfo
+
+// ObjectListFunc is the type of the function called for each object visited
+// by ListObjects.
+type ObjectListFunc = radostypes.ObjectListFunc
+
+// OmapKeyValue items are returned by the Next call of an OmapIterator.
+type OmapKeyValue = radostypes.OmapKeyValue
+
+// OmapListFunc is the type of the function called for each omap key
+// visited by ListOmapValues
+type OmapListFunc = radostypes.OmapListFunc
+
+// OperationError is an error type that may be returned by an Operate call.
+// It captures the error from the operate call itself and any errors from
+// steps that can return an error.
+type OperationError = radostypes.OperationError
+
+var (
+	// ErrOperationIncomplete is returned from write op or read op steps for
+	// which the operation has not been performed yet.
+	ErrOperationIncomplete = radostypes.ErrOperationIncomplete
+)
+
+const (
+	// ErrNotFound indicates a missing resource.
+	ErrNotFound = radostypes.ErrNotFound
+	// ErrPermissionDenied indicates a permissions issue.
+	ErrPermissionDenied = radostypes.ErrPermissionDenied
+	// ErrObjectExists indicates that an exclusive object creation failed.
+	ErrObjectExists = radostypes.ErrObjectExists
+)
+
+// ReadOpReadStep holds the result of the Read step of a ReadOperation.
+// The result is valid only after Operate was called.
+type ReadOpReadStep struct {
+	BytesRead int64 // Bytes read by this action.
+	Result    int   // Result of this action.
+}
+
+// ReadOpStatStep holds the result of the Stat step of a ReadOperation.
+// The result is valid only after Operate was called.
+type ReadOpStatStep struct {
+	Size    uint64    // Size of the object.
+	ModTime time.Time // Last modification time of the object.
+	Result  int       // Result of this action.
+}
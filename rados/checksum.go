@@ -0,0 +1,87 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+//
+import "C"
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ChecksumType is used to select the algorithm used to checksum object
+// data.
+type ChecksumType int
+
+const (
+	// ChecksumXXHash32 selects the 32 bit xxHash algorithm.
+	ChecksumXXHash32 = ChecksumType(C.LIBRADOS_CHECKSUM_TYPE_XXHASH32)
+	// ChecksumXXHash64 selects the 64 bit xxHash algorithm.
+	ChecksumXXHash64 = ChecksumType(C.LIBRADOS_CHECKSUM_TYPE_XXHASH64)
+	// ChecksumCRC32C selects the CRC32C algorithm.
+	ChecksumCRC32C = ChecksumType(C.LIBRADOS_CHECKSUM_TYPE_CRC32C)
+)
+
+var errChecksumResult = errors.New("invalid checksum result")
+
+// size returns the size in bytes of a single checksum value of the type.
+func (t ChecksumType) size() int {
+	if t == ChecksumXXHash64 {
+		return 8
+	}
+	return 4
+}
+
+// initValue encodes the initial value (seed) of a checksum in the format
+// expected by librados.
+func (t ChecksumType) initValue(init uint64) []byte {
+	b := make([]byte, t.size())
+	if t.size() == 8 {
+		binary.LittleEndian.PutUint64(b, init)
+	} else {
+		binary.LittleEndian.PutUint32(b, uint32(init))
+	}
+	return b
+}
+
+// checksumCount returns the number of checksums computed for length bytes
+// of data split into chunks of chunkSize bytes.
+func checksumCount(length, chunkSize uint64) int {
+	if chunkSize == 0 || length == 0 {
+		return 1
+	}
+	return int((length + chunkSize - 1) / chunkSize)
+}
+
+// resultSize returns the size of the buffer needed to hold count checksums
+// of the type.
+func (t ChecksumType) resultSize(count int) int {
+	// the checksums are preceded by a 32 bit count
+	return 4 + count*t.size()
+}
+
+// parseResult converts the checksum values returned by librados to Go
+// values.
+func (t ChecksumType) parseResult(b []byte) ([]uint64, error) {
+	if len(b) < 4 {
+		return nil, errChecksumResult
+	}
+	count := int(binary.LittleEndian.Uint32(b))
+	b = b[4:]
+	if len(b) < count*t.size() {
+		return nil, errChecksumResult
+	}
+	sums := make([]uint64, count)
+	for i := range sums {
+		if t.size() == 8 {
+			sums[i] = binary.LittleEndian.Uint64(b[i*8:])
+		} else {
+			sums[i] = uint64(binary.LittleEndian.Uint32(b[i*4:]))
+		}
+	}
+	return sums, nil
+}
@@ -0,0 +1,55 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumType(t *testing.T) {
+	t.Run("initValue", func(t *testing.T) {
+		assert.Equal(t,
+			[]byte{0x04, 0x03, 0x02, 0x01},
+			ChecksumCRC32C.initValue(0x01020304))
+		assert.Equal(t,
+			[]byte{0x04, 0x03, 0x02, 0x01},
+			ChecksumXXHash32.initValue(0x01020304))
+		assert.Equal(t,
+			[]byte{0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01},
+			ChecksumXXHash64.initValue(0x0102030405060708))
+	})
+
+	t.Run("count", func(t *testing.T) {
+		assert.Equal(t, 1, checksumCount(0, 0))
+		assert.Equal(t, 1, checksumCount(8192, 0))
+		assert.Equal(t, 2, checksumCount(8192, 4096))
+		assert.Equal(t, 3, checksumCount(8193, 4096))
+		assert.Equal(t, 12, ChecksumCRC32C.resultSize(2))
+		assert.Equal(t, 20, ChecksumXXHash64.resultSize(2))
+	})
+
+	t.Run("parseResult", func(t *testing.T) {
+		sums, err := ChecksumCRC32C.parseResult([]byte{
+			0x02, 0x00, 0x00, 0x00,
+			0x01, 0x00, 0x00, 0x00,
+			0xff, 0xff, 0xff, 0xff,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{1, 0xffffffff}, sums)
+
+		sums, err = ChecksumXXHash64.parseResult([]byte{
+			0x01, 0x00, 0x00, 0x00,
+			0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{0x0102030405060708}, sums)
+
+		_, err = ChecksumXXHash64.parseResult([]byte{0x01, 0x00, 0x00, 0x00})
+		assert.Equal(t, errChecksumResult, err)
+		_, err = ChecksumXXHash64.parseResult([]byte{0x01})
+		assert.Equal(t, errChecksumResult, err)
+	})
+}
@@ -0,0 +1,137 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// librados has neither an asynchronous API nor a timeout per call for
+// commands. Instead of leaving a goroutine behind, the context aware
+// variants of the command calls make the blocking call with the
+// rados_mon_op_timeout option of the connection lowered to the deadline of
+// the context. The option applies to the whole connection, so these calls
+// take turns per Conn and the previous value is restored afterwards.
+
+const monOpTimeoutOption = "rados_mon_op_timeout"
+
+var (
+	// the context aware commands of each Conn are serialized on a channel
+	// with a single slot, so that waiting for a turn can be cancelled
+	commandSlots    = map[*Conn]chan struct{}{}
+	commandSlotsMtx sync.Mutex
+)
+
+func commandSlot(c *Conn) chan struct{} {
+	commandSlotsMtx.Lock()
+	defer commandSlotsMtx.Unlock()
+	slot, ok := commandSlots[c]
+	if !ok {
+		slot = make(chan struct{}, 1)
+		commandSlots[c] = slot
+	}
+	return slot
+}
+
+// dropCommandSlot forgets the command slot of a Conn that is shut down.
+func dropCommandSlot(c *Conn) {
+	commandSlotsMtx.Lock()
+	defer commandSlotsMtx.Unlock()
+	delete(commandSlots, c)
+}
+
+func init() {
+	shutdownHooks = append(shutdownHooks, dropCommandSlot)
+}
+
+// timeoutSeconds returns the value of rados_mon_op_timeout for the given
+// duration. The option takes whole seconds, so the duration is rounded up.
+func timeoutSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
+
+func (c *Conn) commandContext(ctx context.Context, cmd func() ([]byte, string, error)) (buf []byte, status string, err error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return cmd()
+	}
+
+	slot := commandSlot(c)
+	select {
+	case slot <- struct{}{}:
+	case <-ctx.Done():
+		return nil, "", ctx.Err()
+	}
+	defer func() { <-slot }()
+
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return nil, "", context.DeadlineExceeded
+	}
+	prev, err := c.GetConfigOption(monOpTimeoutOption)
+	if err != nil {
+		return nil, "", err
+	}
+	secs, perr := strconv.ParseFloat(prev, 64)
+	if perr != nil || secs <= 0 || secs > timeout.Seconds() {
+		// the connection would wait past the deadline
+		err = c.SetConfigOption(monOpTimeoutOption, timeoutSeconds(timeout))
+		if err != nil {
+			return nil, "", err
+		}
+		defer func() {
+			rerr := c.SetConfigOption(monOpTimeoutOption, prev)
+			if err == nil {
+				err = rerr
+			}
+		}()
+	}
+
+	buf, status, err = cmd()
+	if err != nil && ctx.Err() != nil {
+		return nil, "", ctx.Err()
+	}
+	return buf, status, err
+}
+
+// MonCommandContext sends a command to one of the monitors, like
+// MonCommand. It returns ctx.Err() if the context is done before the
+// command is sent, or if the deadline of the context passes before a reply
+// is received. Cancelling the context does not interrupt a command that was
+// sent already. While a command with a deadline is in progress, the
+// rados_mon_op_timeout option of the connection is lowered to the deadline.
+func (c *Conn) MonCommandContext(ctx context.Context, args []byte) ([]byte, string, error) {
+	return c.MonCommandWithInputBufferContext(ctx, args, nil)
+}
+
+// MonCommandWithInputBufferContext sends a command, with an input buffer, to
+// one of the monitors, like MonCommandWithInputBuffer. The context is
+// handled as by MonCommandContext.
+func (c *Conn) MonCommandWithInputBufferContext(ctx context.Context, args, inputBuffer []byte) ([]byte, string, error) {
+	return c.commandContext(ctx, func() ([]byte, string, error) {
+		return c.MonCommandWithInputBuffer(args, inputBuffer)
+	})
+}
+
+// MgrCommandContext sends a command to a ceph-mgr, like MgrCommand. The
+// context is handled as by MonCommandContext.
+func (c *Conn) MgrCommandContext(ctx context.Context, args [][]byte) ([]byte, string, error) {
+	return c.MgrCommandWithInputBufferContext(ctx, args, nil)
+}
+
+// MgrCommandWithInputBufferContext sends a command, with an input buffer, to
+// a ceph-mgr, like MgrCommandWithInputBuffer. The context is handled as by
+// MonCommandContext.
+func (c *Conn) MgrCommandWithInputBufferContext(ctx context.Context, args [][]byte, inputBuffer []byte) ([]byte, string, error) {
+	return c.commandContext(ctx, func() ([]byte, string, error) {
+		return c.MgrCommandWithInputBuffer(args, inputBuffer)
+	})
+}
@@ -0,0 +1,31 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+//
+import "C"
+
+// CompareOp is used to select how values are compared by the xattr and omap
+// comparison actions of read and write operations.
+type CompareOp uint8
+
+const (
+	// CompareEqual requires the values to be equal.
+	CompareEqual = CompareOp(C.LIBRADOS_CMPXATTR_OP_EQ)
+	// CompareNotEqual requires the values to differ.
+	CompareNotEqual = CompareOp(C.LIBRADOS_CMPXATTR_OP_NE)
+	// CompareGreater requires the first value to be greater than the
+	// second.
+	CompareGreater = CompareOp(C.LIBRADOS_CMPXATTR_OP_GT)
+	// CompareGreaterEqual requires the first value to be greater than or
+	// equal to the second.
+	CompareGreaterEqual = CompareOp(C.LIBRADOS_CMPXATTR_OP_GTE)
+	// CompareLess requires the first value to be less than the second.
+	CompareLess = CompareOp(C.LIBRADOS_CMPXATTR_OP_LT)
+	// CompareLessEqual requires the first value to be less than or equal to
+	// the second.
+	CompareLessEqual = CompareOp(C.LIBRADOS_CMPXATTR_OP_LTE)
+)
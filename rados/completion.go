@@ -0,0 +1,143 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+/*
+#cgo LDFLAGS: -lrados
+#include <stdlib.h>
+#include <rados/librados.h>
+
+// inline wrapper to cast uintptr_t to void*
+static inline int wrap_rados_aio_create_completion(void *cb, uintptr_t arg,
+	rados_completion_t *pc) {
+		return rados_aio_create_completion((void*)arg,
+			(rados_callback_t)cb, NULL, pc);
+	};
+*/
+import "C"
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/aio"
+)
+
+// completionOps are the librados calls managing the C completions.
+var completionOps = &aio.Ops{
+	Create: func(cb unsafe.Pointer, arg uintptr) (unsafe.Pointer, int) {
+		var c C.rados_completion_t
+		ret := C.wrap_rados_aio_create_completion(cb, C.uintptr_t(arg), &c)
+		return unsafe.Pointer(c), int(ret)
+	},
+	Release: func(c unsafe.Pointer) {
+		C.rados_aio_release(C.rados_completion_t(c))
+	},
+	ReturnValue: func(c unsafe.Pointer) int {
+		return int(C.rados_aio_get_return_value(C.rados_completion_t(c)))
+	},
+	Version: func(c unsafe.Pointer) uint64 {
+		return uint64(C.rados_aio_get_version(C.rados_completion_t(c)))
+	},
+	Error: func(ret int) error {
+		return getErrorIfNegative(C.int(ret))
+	},
+}
+
+// CompletionCallback is the type of function that can be registered with a
+// Completion to be called when the asynchronous operation is complete.
+type CompletionCallback func(*Completion)
+
+// completionFinisher is called once the asynchronous operation is complete.
+// It is passed the return value of the operation and is expected to convert
+// any C level outputs to Go values and return the error for the operation.
+type completionFinisher func(ret C.int) error
+
+// Completion represents an asynchronous operation that has been submitted to
+// the cluster. The results of the operation, and any output values passed to
+// the call that created the Completion, are valid only once the Completion is
+// complete. The Completion releases all of its C resources on its own when
+// the operation is complete.
+type Completion struct {
+	aio *aio.Completion
+	// c is the C completion passed to the call starting the operation
+	c C.rados_completion_t
+}
+
+func newCompletion(finish completionFinisher, free func()) (*Completion, error) {
+	var f aio.Finisher
+	if finish != nil {
+		f = func(ret int) error { return finish(C.int(ret)) }
+	}
+	ac, err := aio.New(completionOps, f, free)
+	if err != nil {
+		return nil, err
+	}
+	return &Completion{aio: ac, c: C.rados_completion_t(ac.Handle())}, nil
+}
+
+// submitted must be called with the return value of the C function that
+// started the asynchronous operation. If the operation could not be started
+// the resources of the completion are freed and an error is returned.
+func (comp *Completion) submitted(ret C.int) (*Completion, error) {
+	if err := comp.aio.Submitted(int(ret)); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+// Done returns a channel that is closed when the asynchronous operation is
+// complete.
+func (comp *Completion) Done() <-chan struct{} {
+	return comp.aio.Done()
+}
+
+// IsComplete returns true if the asynchronous operation is complete.
+func (comp *Completion) IsComplete() bool {
+	return comp.aio.IsComplete()
+}
+
+// Wait blocks until the asynchronous operation is complete and returns the
+// error, if any, of the operation.
+func (comp *Completion) Wait() error {
+	return comp.aio.Wait()
+}
+
+// waitContext blocks until the asynchronous operation is complete, and
+// returns its error, or until the context is done. In the latter case the
+// Completion is abandoned and ctx.Err() is returned. The operation
+// continues in the cluster but its outputs are left untouched, and the
+// resources of the Completion are released once it is complete.
+func (comp *Completion) waitContext(ctx context.Context) error {
+	return comp.aio.WaitContext(ctx)
+}
+
+// Result returns the return value of the asynchronous operation and its
+// error. For reads the return value is the number of bytes read.
+// If the operation is not yet complete ErrOperationIncomplete is returned.
+func (comp *Completion) Result() (int, error) {
+	if !comp.IsComplete() {
+		return 0, ErrOperationIncomplete
+	}
+	return comp.aio.Result()
+}
+
+// Version returns the version of the object the asynchronous operation was
+// performed on. If the operation is not yet complete ErrOperationIncomplete
+// is returned.
+func (comp *Completion) Version() (uint64, error) {
+	if !comp.IsComplete() {
+		return 0, ErrOperationIncomplete
+	}
+	return comp.aio.Version(), nil
+}
+
+// OnComplete registers a function to be called once the asynchronous
+// operation is complete. If the operation is already complete the function is
+// called immediately. Otherwise the function is called from a thread owned by
+// librados and should return quickly, as it delays the completion of other
+// operations.
+func (comp *Completion) OnComplete(cb CompletionCallback) {
+	comp.aio.OnComplete(func() { cb(comp) })
+}
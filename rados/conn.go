@@ -69,11 +69,18 @@ func (c *Conn) Connect() error {
 	return nil
 }
 
+// shutdownHooks are called with a Conn before it is shut down, to release
+// the resources tied to the connection.
+var shutdownHooks []func(c *Conn)
+
 // Shutdown disconnects from the cluster.
 func (c *Conn) Shutdown() {
 	if err := c.ensureConnected(); err != nil {
 		return
 	}
+	for _, hook := range shutdownHooks {
+		hook(c)
+	}
 	freeConn(c)
 }
 
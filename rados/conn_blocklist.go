@@ -0,0 +1,38 @@
+//go:build !(nautilus || octopus) && ceph_preview
+// +build !nautilus,!octopus,ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// BlocklistAdd blocklists the client with the given address, like
+// "10.0.0.1:0/3710147553", preventing it from accessing the cluster. The
+// entry expires after the given duration, rounded down to seconds. If the
+// duration is zero the cluster's default expiry, as configured by
+// mon_osd_blocklist_default_expire, is used.
+//
+// Implements:
+//
+//	int rados_blocklist_add(rados_t cluster,
+//	                        char *client_address,
+//	                        uint32_t expire_seconds);
+func (c *Conn) BlocklistAdd(addr string, expire time.Duration) error {
+	if err := c.ensureConnected(); err != nil {
+		return err
+	}
+	cAddr := C.CString(addr)
+	defer C.free(unsafe.Pointer(cAddr))
+	ret := C.rados_blocklist_add(
+		c.cluster,
+		cAddr,
+		C.uint32_t(expire/time.Second))
+	return getError(ret)
+}
@@ -0,0 +1,61 @@
+//go:build !(nautilus || octopus) && ceph_preview
+// +build !nautilus,!octopus,ceph_preview
+
+package rados
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestBlocklistAdd() {
+	suite.SetupConnection()
+
+	// fence a separate connection, the one of the suite is still needed
+	conn, err := NewConn()
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), conn.ReadDefaultConfigFile())
+	require.NoError(suite.T(), conn.Connect())
+	defer conn.Shutdown()
+
+	addr, err := conn.GetAddrs()
+	require.NoError(suite.T(), err)
+
+	err = suite.conn.BlocklistAdd(addr, time.Hour)
+	require.NoError(suite.T(), err)
+	defer func() {
+		cmd, err := json.Marshal(map[string]string{
+			"prefix":      "osd blocklist",
+			"blocklistop": "rm",
+			"addr":        addr,
+		})
+		require.NoError(suite.T(), err)
+		_, _, err = suite.conn.MonCommand(cmd)
+		assert.NoError(suite.T(), err)
+	}()
+
+	cmd, err := json.Marshal(map[string]string{
+		"prefix": "osd blocklist ls",
+		"format": "json",
+	})
+	require.NoError(suite.T(), err)
+	buf, _, err := suite.conn.MonCommand(cmd)
+	require.NoError(suite.T(), err)
+	entries := []struct {
+		Addr string `json:"addr"`
+	}{}
+	require.NoError(suite.T(), json.Unmarshal(buf, &entries))
+	found := false
+	for _, e := range entries {
+		found = found || e.Addr == addr
+	}
+	assert.True(suite.T(), found)
+}
+
+func (suite *RadosTestSuite) TestBlocklistAddNotConnected() {
+	err := suite.conn.BlocklistAdd("192.0.2.17:0/1", 0)
+	assert.Equal(suite.T(), ErrNotConnected, err)
+}
@@ -0,0 +1,32 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// GetAddrs returns the addresses of the connection as seen by the cluster.
+// This is the address of the client that can be blocklisted to fence it.
+//
+// Implements:
+//
+//	int rados_getaddrs(rados_t cluster, char** addrs);
+func (c *Conn) GetAddrs() (string, error) {
+	if err := c.ensureConnected(); err != nil {
+		return "", err
+	}
+	var cAddrs *C.char
+	ret := C.rados_getaddrs(c.cluster, &cAddrs)
+	if ret < 0 {
+		return "", getError(ret)
+	}
+	defer C.free(unsafe.Pointer(cAddrs))
+	return C.GoString(cAddrs), nil
+}
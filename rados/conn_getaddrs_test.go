@@ -0,0 +1,18 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestGetAddrs() {
+	_, err := suite.conn.GetAddrs()
+	assert.Equal(suite.T(), ErrNotConnected, err)
+
+	suite.SetupConnection()
+	addrs, err := suite.conn.GetAddrs()
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), addrs)
+}
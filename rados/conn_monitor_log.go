@@ -0,0 +1,250 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+/*
+#cgo LDFLAGS: -lrados
+#include <stdlib.h>
+#include <rados/librados.h>
+
+extern void logMonitorCallback(void*, char*, char*, char*, char*,
+	uint64_t, uint64_t, uint64_t, char*, char*);
+
+// inline wrapper to cast uintptr_t to void*
+static inline int wrap_rados_monitor_log2(rados_t cluster,
+	const char *level, uintptr_t arg) {
+		return rados_monitor_log2(cluster, level,
+			(rados_log_callback2_t)logMonitorCallback, (void*)arg);
+	};
+*/
+import "C"
+
+import (
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/callbacks"
+)
+
+// LogLevel selects the minimum severity of the cluster log entries received
+// by a LogMonitor.
+type LogLevel string
+
+const (
+	// LogLevelDebug selects all log entries.
+	LogLevelDebug = LogLevel("debug")
+	// LogLevelInfo selects informational log entries and above.
+	LogLevelInfo = LogLevel("info")
+	// LogLevelWarn selects warnings and above.
+	LogLevelWarn = LogLevel("warn")
+	// LogLevelError selects errors and above.
+	LogLevelError = LogLevel("error")
+	// LogLevelSecurity selects security related log entries only.
+	LogLevelSecurity = LogLevel("sec")
+)
+
+const logMonitorBufferSize = 64
+
+// LogEntry is an entry of the cluster log received by a LogMonitor.
+type LogEntry struct {
+	// Who is the address of the entity that logged the entry.
+	Who string
+	// Name is the name of the entity that logged the entry, e.g. "mon.a".
+	Name string
+	// Stamp is the time the entry was logged.
+	Stamp time.Time
+	// Seq is the sequence number of the entry.
+	Seq uint64
+	// Level is the severity of the entry, e.g. "[WRN]".
+	Level string
+	// Channel is the log channel of the entry, e.g. "cluster" or "audit".
+	Channel string
+	// Message is the text of the entry.
+	Message string
+	// Line is the complete log line as formatted by Ceph.
+	Line string
+}
+
+// LogMonitor receives the entries of the cluster log. The entries are
+// delivered on the channel returned by Entries until Stop is called.
+type LogMonitor struct {
+	conn    *Conn
+	channel string
+	cbIndex uintptr
+	entries chan LogEntry
+
+	// mutex protects the entries channel from being closed while an entry
+	// is sent to it
+	mutex   sync.Mutex
+	stopped bool
+	dropped uint64
+}
+
+var (
+	logMonitorCallbacks = callbacks.New()
+
+	// librados supports a single log callback per cluster connection, the
+	// active LogMonitor of each Conn is tracked here
+	logMonitors    = map[*Conn]*LogMonitor{}
+	logMonitorsMtx sync.Mutex
+)
+
+// MonitorLog starts receiving the entries of the cluster log with a
+// severity of at least level. If channel is not empty only entries of the
+// given log channel, e.g. "cluster" or "audit", are received. A Conn can
+// only have one active LogMonitor. Starting a new LogMonitor stops the
+// previous one.
+//
+// The entries are delivered from a thread owned by librados, which must
+// not be blocked. Entries that arrive while the Entries channel is full are
+// dropped and counted, see Dropped.
+//
+// Implements:
+//
+//	int rados_monitor_log2(rados_t cluster, const char *level,
+//	                       rados_log_callback2_t cb, void *arg);
+func (c *Conn) MonitorLog(level LogLevel, channel string) (*LogMonitor, error) {
+	if err := c.ensureConnected(); err != nil {
+		return nil, err
+	}
+
+	logMonitorsMtx.Lock()
+	defer logMonitorsMtx.Unlock()
+	prev := logMonitors[c]
+	if prev != nil {
+		// the previous monitor is replaced by registering the new one
+		prev.halt()
+		delete(logMonitors, c)
+		defer logMonitorCallbacks.Remove(prev.cbIndex)
+	}
+
+	m := &LogMonitor{
+		conn:    c,
+		channel: channel,
+		entries: make(chan LogEntry, logMonitorBufferSize),
+	}
+	m.cbIndex = logMonitorCallbacks.Add(m)
+
+	cLevel := C.CString(string(level))
+	defer C.free(unsafe.Pointer(cLevel))
+	ret := C.wrap_rados_monitor_log2(
+		c.cluster, cLevel, C.uintptr_t(m.cbIndex))
+	if err := getError(ret); err != nil {
+		logMonitorCallbacks.Remove(m.cbIndex)
+		return nil, err
+	}
+	logMonitors[c] = m
+	return m, nil
+}
+
+// Entries returns the channel the log entries are delivered on. The channel
+// is closed when the LogMonitor is stopped.
+func (m *LogMonitor) Entries() <-chan LogEntry {
+	return m.entries
+}
+
+// Dropped returns the number of entries that were dropped because the
+// Entries channel was full.
+func (m *LogMonitor) Dropped() uint64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.dropped
+}
+
+// Stop stops receiving the entries of the cluster log and closes the
+// Entries channel. A LogMonitor is also stopped when its Conn is shut down.
+//
+// Implements:
+//
+//	int rados_monitor_log2(rados_t cluster, const char *level,
+//	                       rados_log_callback2_t cb, void *arg);
+func (m *LogMonitor) Stop() error {
+	logMonitorsMtx.Lock()
+	defer logMonitorsMtx.Unlock()
+	if logMonitors[m.conn] != m {
+		// already stopped or replaced by another LogMonitor
+		return nil
+	}
+	return m.stop()
+}
+
+// stop halts the LogMonitor and unregisters it from librados. The caller
+// must hold logMonitorsMtx.
+func (m *LogMonitor) stop() error {
+	delete(logMonitors, m.conn)
+	// librados holds its client lock while calling the log callback, and
+	// rados_monitor_log2 takes the same lock, so the delivery is halted
+	// before unregistering
+	m.halt()
+	var err error
+	if m.conn.ensureConnected() == nil {
+		ret := C.rados_monitor_log2(m.conn.cluster, nil, nil, nil)
+		err = getError(ret)
+	}
+	logMonitorCallbacks.Remove(m.cbIndex)
+	return err
+}
+
+// halt stops the delivery of entries and closes the Entries channel.
+func (m *LogMonitor) halt() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.stopped {
+		return
+	}
+	m.stopped = true
+	close(m.entries)
+}
+
+// stopLogMonitor stops the active LogMonitor of a Conn that is shut down.
+func stopLogMonitor(c *Conn) {
+	logMonitorsMtx.Lock()
+	defer logMonitorsMtx.Unlock()
+	if m, ok := logMonitors[c]; ok {
+		_ = m.stop()
+	}
+}
+
+func init() {
+	shutdownHooks = append(shutdownHooks, stopLogMonitor)
+}
+
+func (m *LogMonitor) deliver(entry LogEntry) {
+	if m.channel != "" && m.channel != entry.Channel {
+		return
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.stopped {
+		return
+	}
+	select {
+	case m.entries <- entry:
+	default:
+		m.dropped++
+	}
+}
+
+//export logMonitorCallback
+func logMonitorCallback(
+	arg unsafe.Pointer, line, channel, who, name *C.char,
+	sec, nsec, seq C.uint64_t, level, msg *C.char) {
+
+	v := logMonitorCallbacks.Lookup(uintptr(arg))
+	m, ok := v.(*LogMonitor)
+	if !ok {
+		return
+	}
+	m.deliver(LogEntry{
+		Who:     C.GoString(who),
+		Name:    C.GoString(name),
+		Stamp:   time.Unix(int64(sec), int64(nsec)),
+		Seq:     uint64(seq),
+		Level:   C.GoString(level),
+		Channel: C.GoString(channel),
+		Message: C.GoString(msg),
+		Line:    C.GoString(line),
+	})
+}
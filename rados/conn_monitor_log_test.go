@@ -0,0 +1,117 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestMonitorLog() {
+	suite.SetupConnection()
+
+	suite.T().Run("notConnected", func(t *testing.T) {
+		conn, err := NewConn()
+		require.NoError(t, err)
+		_, err = conn.MonitorLog(LogLevelInfo, "")
+		assert.Equal(t, ErrNotConnected, err)
+	})
+
+	suite.T().Run("receive", func(t *testing.T) {
+		m, err := suite.conn.MonitorLog(LogLevelInfo, "cluster")
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, m.Stop()) }()
+
+		text := "go-ceph test " + uuid.Must(uuid.NewV4()).String()
+		cmd, err := json.Marshal(map[string]interface{}{
+			"prefix":  "log",
+			"logtext": []string{text},
+		})
+		require.NoError(t, err)
+		_, _, err = suite.conn.MonCommand(cmd)
+		require.NoError(t, err)
+
+		timeout := time.After(30 * time.Second)
+		for {
+			select {
+			case entry, ok := <-m.Entries():
+				require.True(t, ok)
+				assert.Equal(t, "cluster", entry.Channel)
+				if !strings.Contains(entry.Message, text) {
+					continue
+				}
+				assert.NotEmpty(t, entry.Name)
+				assert.False(t, entry.Stamp.IsZero())
+				return
+			case <-timeout:
+				t.Fatalf("timed out waiting for log entry")
+			}
+		}
+	})
+
+	suite.T().Run("replaceAndStop", func(t *testing.T) {
+		m1, err := suite.conn.MonitorLog(LogLevelDebug, "")
+		require.NoError(t, err)
+		m2, err := suite.conn.MonitorLog(LogLevelWarn, "")
+		require.NoError(t, err)
+
+		// the first monitor is stopped by starting the second one
+		for range m1.Entries() {
+		}
+		assert.NoError(t, m1.Stop())
+
+		assert.NoError(t, m2.Stop())
+		for range m2.Entries() {
+		}
+		// stopping again is harmless
+		assert.NoError(t, m2.Stop())
+	})
+
+	suite.T().Run("shutdown", func(t *testing.T) {
+		conn, err := NewConn()
+		require.NoError(t, err)
+		require.NoError(t, conn.ReadDefaultConfigFile())
+		require.NoError(t, conn.Connect())
+		m, err := conn.MonitorLog(LogLevelInfo, "")
+		require.NoError(t, err)
+
+		conn.Shutdown()
+		for range m.Entries() {
+		}
+		logMonitorsMtx.Lock()
+		_, ok := logMonitors[conn]
+		logMonitorsMtx.Unlock()
+		assert.False(t, ok)
+		assert.NoError(t, m.Stop())
+	})
+}
+
+func TestLogMonitorDeliver(t *testing.T) {
+	m := &LogMonitor{
+		channel: "cluster",
+		entries: make(chan LogEntry, 2),
+	}
+	m.deliver(LogEntry{Channel: "audit"})
+	for i := 0; i < 5; i++ {
+		m.deliver(LogEntry{Channel: "cluster", Seq: uint64(i)})
+	}
+	// a full channel does not block the delivery
+	assert.Len(t, m.entries, 2)
+	assert.EqualValues(t, 3, m.Dropped())
+
+	m.halt()
+	m.deliver(LogEntry{Channel: "cluster"})
+	assert.EqualValues(t, 3, m.Dropped())
+	seqs := []uint64{}
+	for entry := range m.Entries() {
+		seqs = append(seqs, entry.Seq)
+	}
+	assert.Equal(t, []uint64{0, 1}, seqs)
+}
@@ -0,0 +1,137 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+var (
+	// errInvalidDict is returned if a key or value of a service metadata or
+	// status map can not be passed to librados.
+	errInvalidDict = errors.New("service map keys must be non-empty and keys and values must not contain NUL")
+	// errInvalidInterval is returned if the interval of ReportServiceStatus
+	// is not positive.
+	errInvalidInterval = errors.New("service status interval must be positive")
+)
+
+// ServiceMetadata is the static metadata of a service daemon. It is
+// reported, along with information about the host the daemon runs on, when
+// the daemon is registered.
+type ServiceMetadata map[string]string
+
+// ServiceStatus is the dynamic status of a service daemon.
+type ServiceStatus map[string]string
+
+// ServiceStatusFunc is called by ReportServiceStatus to get the current
+// status of the service daemon.
+type ServiceStatusFunc func() ServiceStatus
+
+// encodeDict converts a map to the null separated key and value list, with
+// an empty key marking the end, that librados expects for service metadata
+// and status. The keys are sorted to make the result deterministic.
+func encodeDict(m map[string]string) (string, error) {
+	keys := make([]string, 0, len(m))
+	for k, v := range m {
+		if k == "" || strings.ContainsRune(k, 0) || strings.ContainsRune(v, 0) {
+			return "", errInvalidDict
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k)
+		b.WriteByte(0)
+		b.WriteString(m[k])
+		b.WriteByte(0)
+	}
+	// the final null byte that terminates the list is added by C.CString
+	return b.String(), nil
+}
+
+// ServiceRegister registers the connection as the daemon named daemon of
+// the service named service, making it visible in the service map of the
+// cluster (e.g. `ceph service dump`). The service name must not be the name
+// of a core ceph service, like "osd" or "mon". A connection can only be
+// registered once. ServiceRegister may be called before or after the
+// connection is established.
+//
+// Implements:
+//
+//	int rados_service_register(rados_t cluster, const char *service,
+//	                           const char *daemon,
+//	                           const char *metadata_dict);
+func (c *Conn) ServiceRegister(service, daemon string, metadata ServiceMetadata) error {
+	dict, err := encodeDict(metadata)
+	if err != nil {
+		return err
+	}
+	cMetadata := C.CString(dict)
+	defer C.free(unsafe.Pointer(cMetadata))
+	cService := C.CString(service)
+	defer C.free(unsafe.Pointer(cService))
+	cDaemon := C.CString(daemon)
+	defer C.free(unsafe.Pointer(cDaemon))
+
+	ret := C.rados_service_register(c.cluster, cService, cDaemon, cMetadata)
+	return getError(ret)
+}
+
+// ServiceUpdateStatus updates the status of the service daemon registered
+// with ServiceRegister.
+//
+// Implements:
+//
+//	int rados_service_update_status(rados_t cluster,
+//	                                const char *status_dict);
+func (c *Conn) ServiceUpdateStatus(status ServiceStatus) error {
+	if err := c.ensureConnected(); err != nil {
+		return err
+	}
+	dict, err := encodeDict(status)
+	if err != nil {
+		return err
+	}
+	cStatus := C.CString(dict)
+	defer C.free(unsafe.Pointer(cStatus))
+
+	ret := C.rados_service_update_status(c.cluster, cStatus)
+	return getError(ret)
+}
+
+// ReportServiceStatus updates the status of the service daemon registered
+// with ServiceRegister every interval, using the status returned by
+// statusFn. The status is updated once immediately. ReportServiceStatus
+// blocks until the context is done, in which case nil is returned, or an
+// update of the status fails, in which case the error is returned. The
+// interval must be positive.
+func (c *Conn) ReportServiceStatus(ctx context.Context, interval time.Duration, statusFn ServiceStatusFunc) error {
+	if interval <= 0 {
+		return errInvalidInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := c.ServiceUpdateStatus(statusFn()); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
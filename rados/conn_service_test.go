@@ -0,0 +1,119 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDict(t *testing.T) {
+	d, err := encodeDict(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", d)
+
+	d, err = encodeDict(map[string]string{"b": "2", "a": "", "c": "three"})
+	assert.NoError(t, err)
+	assert.Equal(t, "a\x00\x00b\x002\x00c\x00three\x00", d)
+
+	_, err = encodeDict(map[string]string{"": "empty"})
+	assert.Equal(t, errInvalidDict, err)
+	_, err = encodeDict(map[string]string{"a\x00b": "1"})
+	assert.Equal(t, errInvalidDict, err)
+	_, err = encodeDict(map[string]string{"a": "1\x002"})
+	assert.Equal(t, errInvalidDict, err)
+}
+
+func TestReportServiceStatusInterval(t *testing.T) {
+	conn := &Conn{}
+	called := false
+	statusFn := func() ServiceStatus {
+		called = true
+		return nil
+	}
+	err := conn.ReportServiceStatus(context.Background(), 0, statusFn)
+	assert.Equal(t, errInvalidInterval, err)
+	err = conn.ReportServiceStatus(context.Background(), -time.Second, statusFn)
+	assert.Equal(t, errInvalidInterval, err)
+	assert.False(t, called)
+}
+
+func (suite *RadosTestSuite) TestServiceRegister() {
+	suite.SetupConnection()
+
+	// a connection can only be registered once, so use a new one
+	conn, err := NewConn()
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), conn.ReadDefaultConfigFile())
+	require.NoError(suite.T(), conn.Connect())
+	defer conn.Shutdown()
+
+	suite.T().Run("coreService", func(t *testing.T) {
+		err := conn.ServiceRegister("osd", "gotest", nil)
+		assert.Error(t, err)
+	})
+
+	daemon := uuid.Must(uuid.NewV4()).String()
+	err = conn.ServiceRegister("gotest", daemon, ServiceMetadata{
+		"version": "1.0",
+	})
+	require.NoError(suite.T(), err)
+
+	suite.T().Run("registerTwice", func(t *testing.T) {
+		err := conn.ServiceRegister("gotest", daemon, nil)
+		assert.Error(t, err)
+	})
+
+	suite.T().Run("reportStatus", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		calls := 0
+		err := conn.ReportServiceStatus(ctx, 100*time.Millisecond,
+			func() ServiceStatus {
+				calls++
+				return ServiceStatus{"state": "running"}
+			})
+		assert.NoError(t, err)
+		assert.Greater(t, calls, 1)
+	})
+
+	suite.T().Run("serviceDump", func(t *testing.T) {
+		cmd, err := json.Marshal(map[string]string{
+			"prefix": "service dump",
+			"format": "json",
+		})
+		require.NoError(t, err)
+
+		type daemonInfo struct {
+			Metadata map[string]string `json:"metadata"`
+		}
+		var dump struct {
+			Services map[string]struct {
+				Daemons map[string]json.RawMessage `json:"daemons"`
+			} `json:"services"`
+		}
+		// the service map is updated by the mgr asynchronously
+		var found bool
+		for i := 0; i < 30 && !found; i++ {
+			buf, _, err := suite.conn.MgrCommand([][]byte{cmd})
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(buf, &dump))
+			if raw, ok := dump.Services["gotest"].Daemons[daemon]; ok {
+				var info daemonInfo
+				require.NoError(t, json.Unmarshal(raw, &info))
+				assert.Equal(t, "1.0", info.Metadata["version"])
+				found = true
+				break
+			}
+			time.Sleep(time.Second)
+		}
+		assert.True(t, found)
+	})
+}
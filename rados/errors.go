@@ -8,20 +8,11 @@ import "C"
 import (
 	"errors"
 
-	"github.com/ceph/go-ceph/internal/errutil"
+	"github.com/ceph/go-ceph/internal/radostypes"
 )
 
 // radosError represents an error condition returned from the Ceph RADOS APIs.
-type radosError int
-
-// Error returns the error string for the radosError type.
-func (e radosError) Error() string {
-	return errutil.FormatErrorCode("rados", int(e))
-}
-
-func (e radosError) ErrorCode() int {
-	return int(e)
-}
+type radosError = radostypes.Error
 
 func getError(e C.int) error {
 	if e == 0 {
@@ -54,7 +45,7 @@ var (
 	ErrInvalidIOContext = errors.New("IOContext is not ready for use")
 	// ErrOperationIncomplete is returned from write op or read op steps for
 	// which the operation has not been performed yet.
-	ErrOperationIncomplete = errors.New("Operation has not been performed yet")
+	ErrOperationIncomplete = radostypes.ErrOperationIncomplete
 )
 
 // Public radosErrors:
@@ -0,0 +1,148 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+// Cluster is an in-memory cluster holding pools of objects. All connections
+// and I/O contexts of a Cluster share its state. It is safe for concurrent
+// use.
+type Cluster struct {
+	mutex      sync.Mutex
+	pools      map[string]*pool
+	lastPoolID int64
+	lastClient int
+}
+
+// Conn is a connection to a fake Cluster. Each Conn has a unique client
+// name, which identifies it as the holder of locks.
+type Conn struct {
+	cluster *Cluster
+	client  string
+	addr    string
+}
+
+type pool struct {
+	id      int64
+	name    string
+	version uint64
+	objects map[objectKey]*object
+}
+
+type objectKey struct {
+	namespace string
+	oid       string
+}
+
+type object struct {
+	data    []byte
+	xattrs  map[string][]byte
+	omap    map[string][]byte
+	locks   map[string]*lock
+	version uint64
+	mtime   time.Time
+}
+
+// NewCluster returns a new, empty, Cluster.
+func NewCluster() *Cluster {
+	return &Cluster{pools: map[string]*pool{}}
+}
+
+// NewConn returns a new connection to the cluster.
+func (c *Cluster) NewConn() *Conn {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.lastClient++
+	return &Conn{
+		cluster: c,
+		client:  fmt.Sprintf("client.%d", c.lastClient),
+		addr:    fmt.Sprintf("127.0.0.1:0/%d", c.lastClient),
+	}
+}
+
+// MakePool creates a new pool with the given name.
+func (c *Conn) MakePool(name string) error {
+	c.cluster.mutex.Lock()
+	defer c.cluster.mutex.Unlock()
+	if _, ok := c.cluster.pools[name]; ok {
+		return api.ErrObjectExists
+	}
+	c.cluster.lastPoolID++
+	c.cluster.pools[name] = &pool{
+		id:      c.cluster.lastPoolID,
+		name:    name,
+		objects: map[objectKey]*object{},
+	}
+	return nil
+}
+
+// DeletePool deletes the pool with the given name and all of its objects.
+func (c *Conn) DeletePool(name string) error {
+	c.cluster.mutex.Lock()
+	defer c.cluster.mutex.Unlock()
+	if _, ok := c.cluster.pools[name]; !ok {
+		return api.ErrNotFound
+	}
+	delete(c.cluster.pools, name)
+	return nil
+}
+
+// ListPools returns the names of all pools of the cluster.
+func (c *Conn) ListPools() ([]string, error) {
+	c.cluster.mutex.Lock()
+	defer c.cluster.mutex.Unlock()
+	names := make([]string, 0, len(c.cluster.pools))
+	for name := range c.cluster.pools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// OpenIOContext returns an I/O context for the pool with the given name.
+func (c *Conn) OpenIOContext(name string) (*IOContext, error) {
+	c.cluster.mutex.Lock()
+	defer c.cluster.mutex.Unlock()
+	p, ok := c.cluster.pools[name]
+	if !ok {
+		return nil, api.ErrNotFound
+	}
+	return &IOContext{conn: c, pool: p}, nil
+}
+
+func newObject() *object {
+	return &object{
+		xattrs: map[string][]byte{},
+		omap:   map[string][]byte{},
+		locks:  map[string]*lock{},
+	}
+}
+
+func (o *object) clone() *object {
+	c := &object{
+		data:    append([]byte(nil), o.data...),
+		xattrs:  make(map[string][]byte, len(o.xattrs)),
+		omap:    make(map[string][]byte, len(o.omap)),
+		locks:   make(map[string]*lock, len(o.locks)),
+		version: o.version,
+		mtime:   o.mtime,
+	}
+	for k, v := range o.xattrs {
+		c.xattrs[k] = v
+	}
+	for k, v := range o.omap {
+		c.omap[k] = v
+	}
+	for k, v := range o.locks {
+		c.locks[k] = v.clone()
+	}
+	return c
+}
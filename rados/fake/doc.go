@@ -0,0 +1,11 @@
+/*
+Package fake contains an in-memory implementation of the api.IOContextAPI
+interface of the rados/api package, for testing code that uses rados I/O
+contexts without a Ceph cluster.
+
+The implementation follows the semantics of librados for namespaces, object
+versions, compound operations and advisory locks, but it does not implement
+the complete API of the rados package. Like the rados/api package it does
+not use cgo, so it can be built without the Ceph libraries.
+*/
+package fake
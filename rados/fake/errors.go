@@ -0,0 +1,39 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"syscall"
+
+	"github.com/ceph/go-ceph/internal/radostypes"
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+// radosError is the error type of the rados package, so that the errors of
+// the fake are equal to those of librados with the same error code.
+type radosError = radostypes.Error
+
+// the errors returned by librados for conditions that are not represented by
+// one of the public errors of the rados/api package
+const (
+	errNoData   = radosError(-int(syscall.ENODATA))
+	errRange    = radosError(-int(syscall.ERANGE))
+	errOverflow = radosError(-int(syscall.EOVERFLOW))
+)
+
+// return codes of the lock functions
+const (
+	retBusy     = -int(syscall.EBUSY)
+	retExists   = -int(syscall.EEXIST)
+	retNotFound = -int(syscall.ENOENT)
+)
+
+// operationError returns the error of the operation itself, rather than the
+// OperationError wrapping it.
+func operationError(err error) error {
+	if oe, ok := err.(api.OperationError); ok && oe.OpError != nil {
+		return oe.OpError
+	}
+	return err
+}
@@ -0,0 +1,376 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+func newTestIOContext(t *testing.T) (*Cluster, *IOContext) {
+	c := NewCluster()
+	conn := c.NewConn()
+	require.NoError(t, conn.MakePool("pool"))
+	ioctx, err := conn.OpenIOContext("pool")
+	require.NoError(t, err)
+	return c, ioctx
+}
+
+func errorCode(err error) int {
+	var e interface{ ErrorCode() int }
+	if errors.As(err, &e) {
+		return e.ErrorCode()
+	}
+	return 0
+}
+
+func TestPools(t *testing.T) {
+	conn := NewCluster().NewConn()
+	assert.NoError(t, conn.MakePool("a"))
+	assert.NoError(t, conn.MakePool("b"))
+	assert.Equal(t, api.ErrObjectExists, conn.MakePool("a"))
+	pools, err := conn.ListPools()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, pools)
+
+	ioctx, err := conn.OpenIOContext("b")
+	assert.NoError(t, err)
+	name, err := ioctx.GetPoolName()
+	assert.NoError(t, err)
+	assert.Equal(t, "b", name)
+
+	assert.NoError(t, conn.DeletePool("a"))
+	assert.Equal(t, api.ErrNotFound, conn.DeletePool("a"))
+	_, err = conn.OpenIOContext("a")
+	assert.Equal(t, api.ErrNotFound, err)
+}
+
+func TestObjectIO(t *testing.T) {
+	_, ioctx := newTestIOContext(t)
+
+	_, err := ioctx.Stat("obj")
+	assert.Equal(t, api.ErrNotFound, err)
+	_, err = ioctx.Read("obj", make([]byte, 4), 0)
+	assert.Equal(t, api.ErrNotFound, err)
+	assert.Equal(t, api.ErrNotFound, ioctx.Delete("obj"))
+
+	assert.NoError(t, ioctx.Write("obj", []byte("world"), 6))
+	assert.NoError(t, ioctx.Write("obj", []byte("hello "), 0))
+	assert.NoError(t, ioctx.Append("obj", []byte("!")))
+	buf := make([]byte, 32)
+	n, err := ioctx.Read("obj", buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world!", string(buf[:n]))
+	n, err = ioctx.Read("obj", buf, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "world!", string(buf[:n]))
+	n, err = ioctx.Read("obj", buf, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	assert.NoError(t, ioctx.Truncate("obj", 5))
+	st, err := ioctx.Stat("obj")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 5, st.Size)
+	assert.False(t, st.ModTime.IsZero())
+
+	assert.NoError(t, ioctx.WriteFull("obj", []byte("new")))
+	n, err = ioctx.Read("obj", buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(buf[:n]))
+
+	assert.Equal(t, api.ErrObjectExists, ioctx.Create("obj", api.CreateExclusive))
+	assert.NoError(t, ioctx.Create("obj", api.CreateIdempotent))
+	assert.NoError(t, ioctx.Delete("obj"))
+	_, err = ioctx.Stat("obj")
+	assert.Equal(t, api.ErrNotFound, err)
+}
+
+func TestNamespaces(t *testing.T) {
+	c, ioctx := newTestIOContext(t)
+	other, err := c.NewConn().OpenIOContext("pool")
+	require.NoError(t, err)
+
+	assert.NoError(t, ioctx.WriteFull("a", []byte("default")))
+	ioctx.SetNamespace("ns1")
+	ns, err := ioctx.GetNamespace()
+	assert.NoError(t, err)
+	assert.Equal(t, "ns1", ns)
+	assert.NoError(t, ioctx.WriteFull("a", []byte("ns1")))
+	assert.NoError(t, ioctx.WriteFull("b", []byte("ns1")))
+
+	buf := make([]byte, 16)
+	n, err := other.Read("a", buf, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "default", string(buf[:n]))
+	_, err = other.Stat("b")
+	assert.Equal(t, api.ErrNotFound, err)
+
+	list := func(ioctx *IOContext) []string {
+		oids := []string{}
+		assert.NoError(t, ioctx.ListObjects(func(oid string) {
+			oids = append(oids, oid)
+		}))
+		return oids
+	}
+	assert.Equal(t, []string{"a"}, list(other))
+	assert.Equal(t, []string{"a", "b"}, list(ioctx))
+	other.SetNamespace(api.AllNamespaces)
+	assert.Equal(t, []string{"a", "a", "b"}, list(other))
+}
+
+func TestXattrs(t *testing.T) {
+	_, ioctx := newTestIOContext(t)
+
+	_, err := ioctx.ListXattrs("obj")
+	assert.Equal(t, api.ErrNotFound, err)
+	assert.NoError(t, ioctx.SetXattr("obj", "color", []byte("blue")))
+	assert.NoError(t, ioctx.SetXattr("obj", "shape", []byte("square")))
+
+	buf := make([]byte, 16)
+	n, err := ioctx.GetXattr("obj", "color", buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "blue", string(buf[:n]))
+	_, err = ioctx.GetXattr("obj", "color", buf[:2])
+	assert.Equal(t, -34, errorCode(err))
+	_, err = ioctx.GetXattr("obj", "size", buf)
+	assert.Equal(t, -61, errorCode(err))
+
+	assert.NoError(t, ioctx.RmXattr("obj", "color"))
+	xattrs, err := ioctx.ListXattrs("obj")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"shape": []byte("square")}, xattrs)
+}
+
+func TestOmap(t *testing.T) {
+	_, ioctx := newTestIOContext(t)
+
+	_, err := ioctx.GetOmapValues("obj", "", "", 10)
+	assert.Equal(t, api.ErrNotFound, err)
+	assert.Equal(t, api.ErrNotFound, ioctx.CleanOmap("obj"))
+
+	err = ioctx.SetOmap("obj", map[string][]byte{
+		"a1": []byte("1"),
+		"a2": []byte("2"),
+		"b1": []byte("3"),
+		"b2": []byte("4"),
+	})
+	assert.NoError(t, err)
+
+	m, err := ioctx.GetOmapValues("obj", "", "a", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a1": []byte("1"), "a2": []byte("2")}, m)
+	m, err = ioctx.GetOmapValues("obj", "a1", "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a2": []byte("2"), "b1": []byte("3")}, m)
+	m, err = ioctx.GetAllOmapValues("obj", "", "", 1)
+	assert.NoError(t, err)
+	assert.Len(t, m, 4)
+
+	assert.NoError(t, ioctx.RmOmapKeys("obj", []string{"a1", "b2"}))
+	m, err = ioctx.GetAllOmapValues("obj", "", "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a2": []byte("2"), "b1": []byte("3")}, m)
+
+	assert.NoError(t, ioctx.CleanOmap("obj"))
+	m, err = ioctx.GetAllOmapValues("obj", "", "", 10)
+	assert.NoError(t, err)
+	assert.Len(t, m, 0)
+}
+
+func TestVersions(t *testing.T) {
+	_, ioctx := newTestIOContext(t)
+
+	assert.NoError(t, ioctx.WriteFull("a", []byte("1")))
+	v1, err := ioctx.GetLastVersion()
+	assert.NoError(t, err)
+	assert.NoError(t, ioctx.WriteFull("b", []byte("1")))
+	assert.NoError(t, ioctx.WriteFull("a", []byte("2")))
+	v2, err := ioctx.GetLastVersion()
+	assert.NoError(t, err)
+	assert.Greater(t, v2, v1)
+
+	_, err = ioctx.Stat("b")
+	assert.NoError(t, err)
+	vb, err := ioctx.GetLastVersion()
+	assert.NoError(t, err)
+	assert.Less(t, vb, v2)
+
+	op := ioctx.NewWriteOperation()
+	op.AssertVersion(v1)
+	op.WriteFull([]byte("3"))
+	err = op.Operate("a", api.OperationNoFlag)
+	assert.Equal(t, -34, errorCode(err.(api.OperationError).OpError))
+
+	op = ioctx.NewWriteOperation()
+	op.AssertVersion(v2 + 1)
+	op.WriteFull([]byte("3"))
+	err = op.Operate("a", api.OperationNoFlag)
+	assert.Equal(t, -75, errorCode(err.(api.OperationError).OpError))
+
+	op = ioctx.NewWriteOperation()
+	op.AssertVersion(v2)
+	op.WriteFull([]byte("3"))
+	assert.NoError(t, op.Operate("a", api.OperationNoFlag))
+}
+
+func TestOperations(t *testing.T) {
+	_, ioctx := newTestIOContext(t)
+
+	w := ioctx.NewWriteOperation()
+	defer w.Release()
+	w.Create(api.CreateExclusive)
+	w.WriteFull([]byte("data"))
+	w.SetXattr("x", []byte("y"))
+	w.SetOmap(map[string][]byte{"k1": []byte("v1"), "k2": []byte("v2")})
+	assert.NoError(t, w.Operate("obj", api.OperationNoFlag))
+
+	t.Run("atomic", func(t *testing.T) {
+		w := ioctx.NewWriteOperation()
+		defer w.Release()
+		w.Append([]byte("more"))
+		w.Create(api.CreateExclusive)
+		err := w.Operate("obj", api.OperationNoFlag)
+		assert.Error(t, err)
+		st, err := ioctx.Stat("obj")
+		assert.NoError(t, err)
+		assert.EqualValues(t, 4, st.Size)
+	})
+
+	t.Run("read", func(t *testing.T) {
+		r := ioctx.NewReadOperation()
+		defer r.Release()
+		r.AssertExists()
+		buf := make([]byte, 2)
+		rs := r.Read(1, buf)
+		ss := r.Stat()
+		it := r.GetOmapValues("", "", 1)
+		_, err := it.Next()
+		assert.Equal(t, api.ErrOperationIncomplete, err)
+
+		assert.NoError(t, r.Operate("obj", api.OperationNoFlag))
+		assert.EqualValues(t, 2, rs.BytesRead)
+		assert.Equal(t, "at", string(buf))
+		assert.EqualValues(t, 4, ss.Size)
+		kv, err := it.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, "k1", kv.Key)
+		kv, err = it.Next()
+		assert.NoError(t, err)
+		assert.Nil(t, kv)
+		assert.True(t, it.More())
+	})
+
+	t.Run("readMissing", func(t *testing.T) {
+		r := ioctx.NewReadOperation()
+		defer r.Release()
+		r.Stat()
+		err := r.Operate("missing", api.OperationNoFlag)
+		assert.Equal(t, api.ErrNotFound, err.(api.OperationError).OpError)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		w := ioctx.NewWriteOperation()
+		defer w.Release()
+		w.AssertExists()
+		w.Remove()
+		assert.NoError(t, w.Operate("obj", api.OperationNoFlag))
+		_, err := ioctx.Stat("obj")
+		assert.Equal(t, api.ErrNotFound, err)
+	})
+}
+
+func TestLocks(t *testing.T) {
+	c, ioctx1 := newTestIOContext(t)
+	ioctx2, err := c.NewConn().OpenIOContext("pool")
+	require.NoError(t, err)
+
+	t.Run("exclusive", func(t *testing.T) {
+		ret, err := ioctx1.LockExclusive("obj", "l", "c1", "desc", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+		// taking a lock creates the object
+		_, err = ioctx1.Stat("obj")
+		assert.NoError(t, err)
+
+		ret, err = ioctx1.LockExclusive("obj", "l", "c1", "desc", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, -17, ret)
+		ret, err = ioctx2.LockExclusive("obj", "l", "c1", "desc", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, -16, ret)
+
+		info, err := ioctx2.ListLockers("obj", "l")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, info.NumLockers)
+		assert.True(t, info.Exclusive)
+		assert.Equal(t, []string{"client.1"}, info.Clients)
+		assert.Equal(t, []string{"c1"}, info.Cookies)
+
+		ret, err = ioctx2.Unlock("obj", "l", "c1")
+		assert.NoError(t, err)
+		assert.Equal(t, -2, ret)
+		ret, err = ioctx2.BreakLock("obj", "l", "client.1", "c1")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+		info, err = ioctx2.ListLockers("obj", "l")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, info.NumLockers)
+	})
+
+	t.Run("shared", func(t *testing.T) {
+		ret, err := ioctx1.LockShared("obj", "s", "c1", "tag", "", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+		ret, err = ioctx2.LockShared("obj", "s", "c2", "tag", "", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+		ret, err = ioctx2.LockShared("obj", "s", "c3", "other", "", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, -16, ret)
+		ret, err = ioctx2.LockExclusive("obj", "s", "c3", "", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, -16, ret)
+
+		info, err := ioctx1.ListLockers("obj", "s")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, info.NumLockers)
+		assert.False(t, info.Exclusive)
+		assert.Equal(t, "tag", info.Tag)
+
+		ret, err = ioctx1.Unlock("obj", "s", "c1")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+		ret, err = ioctx2.Unlock("obj", "s", "c2")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+	})
+
+	t.Run("expireRenew", func(t *testing.T) {
+		ret, err := ioctx1.LockExclusive("obj", "e", "c1", "", 50*time.Millisecond, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+
+		renew := byte(lockFlagMayRenew)
+		ret, err = ioctx1.LockExclusive("obj", "e", "c1", "", 50*time.Millisecond, &renew)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+
+		mustRenew := byte(lockFlagMustRenew)
+		ret, err = ioctx2.LockExclusive("obj", "e", "c2", "", 0, &mustRenew)
+		assert.NoError(t, err)
+		assert.Equal(t, -2, ret)
+
+		time.Sleep(100 * time.Millisecond)
+		ret, err = ioctx2.LockExclusive("obj", "e", "c2", "", 0, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, ret)
+	})
+}
@@ -0,0 +1,268 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"sort"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+// IOContext is the in-memory implementation of api.IOContextAPI for a
+// pool of a fake Cluster.
+type IOContext struct {
+	conn *Conn
+	pool *pool
+
+	// protected by the mutex of the cluster
+	namespace   string
+	lastVersion uint64
+}
+
+var _ api.IOContextAPI = (*IOContext)(nil)
+
+// Destroy informs the fake that the I/O context is no longer in use. It
+// exists for symmetry with rados.IOContext.
+func (ioctx *IOContext) Destroy() {}
+
+// GetPoolName returns the name of the pool associated with the I/O context.
+func (ioctx *IOContext) GetPoolName() (string, error) {
+	return ioctx.pool.name, nil
+}
+
+// GetPoolID returns the ID of the pool associated with the I/O context.
+func (ioctx *IOContext) GetPoolID() int64 {
+	return ioctx.pool.id
+}
+
+// SetNamespace sets the namespace for objects within this I/O context.
+// Setting namespace to api.AllNamespaces makes ListObjects list the
+// objects of all namespaces.
+func (ioctx *IOContext) SetNamespace(namespace string) {
+	ioctx.conn.cluster.mutex.Lock()
+	defer ioctx.conn.cluster.mutex.Unlock()
+	ioctx.namespace = namespace
+}
+
+// GetNamespace gets the namespace used for objects within this I/O context.
+func (ioctx *IOContext) GetNamespace() (string, error) {
+	ioctx.conn.cluster.mutex.Lock()
+	defer ioctx.conn.cluster.mutex.Unlock()
+	return ioctx.namespace, nil
+}
+
+// GetLastVersion returns the version of the last object read or written.
+func (ioctx *IOContext) GetLastVersion() (uint64, error) {
+	ioctx.conn.cluster.mutex.Lock()
+	defer ioctx.conn.cluster.mutex.Unlock()
+	return ioctx.lastVersion, nil
+}
+
+func (ioctx *IOContext) write(oid string, fn func(w *WriteOp)) error {
+	w := &WriteOp{ioctx: ioctx}
+	fn(w)
+	return operationError(w.Operate(oid, api.OperationNoFlag))
+}
+
+// Read reads up to len(data) bytes from the object with key oid starting at
+// byte offset offset. It returns the number of bytes read.
+func (ioctx *IOContext) Read(oid string, data []byte, offset uint64) (int, error) {
+	r := &ReadOp{ioctx: ioctx}
+	s := r.Read(offset, data)
+	if err := operationError(r.Operate(oid, api.OperationNoFlag)); err != nil {
+		return 0, err
+	}
+	return int(s.BytesRead), nil
+}
+
+// Write writes len(data) bytes to the object with key oid starting at byte
+// offset offset.
+func (ioctx *IOContext) Write(oid string, data []byte, offset uint64) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.Write(data, offset) })
+}
+
+// WriteFull replaces the content of the object with key oid with data.
+func (ioctx *IOContext) WriteFull(oid string, data []byte) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.WriteFull(data) })
+}
+
+// Append appends len(data) bytes to the object with key oid.
+func (ioctx *IOContext) Append(oid string, data []byte) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.Append(data) })
+}
+
+// Truncate resizes the object with key oid to size size.
+func (ioctx *IOContext) Truncate(oid string, size uint64) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.Truncate(size) })
+}
+
+// Create a new object with key oid.
+func (ioctx *IOContext) Create(oid string, exclusive api.CreateOption) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.Create(exclusive) })
+}
+
+// Delete deletes the object with key oid.
+func (ioctx *IOContext) Delete(oid string) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.Remove() })
+}
+
+// Stat returns the size of the object and its last modification time.
+func (ioctx *IOContext) Stat(oid string) (api.ObjectStat, error) {
+	r := &ReadOp{ioctx: ioctx}
+	s := r.Stat()
+	if err := operationError(r.Operate(oid, api.OperationNoFlag)); err != nil {
+		return api.ObjectStat{}, err
+	}
+	return api.ObjectStat{Size: s.Size, ModTime: s.ModTime}, nil
+}
+
+// ListObjects lists all of the objects in the namespace of the I/O context,
+// or in all namespaces if it is set to api.AllNamespaces, in the order of
+// their namespaces and keys.
+func (ioctx *IOContext) ListObjects(listFn api.ObjectListFunc) error {
+	c := ioctx.conn.cluster
+	c.mutex.Lock()
+	keys := []objectKey{}
+	for key := range ioctx.pool.objects {
+		if ioctx.namespace == api.AllNamespaces || key.namespace == ioctx.namespace {
+			keys = append(keys, key)
+		}
+	}
+	c.mutex.Unlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].namespace != keys[j].namespace {
+			return keys[i].namespace < keys[j].namespace
+		}
+		return keys[i].oid < keys[j].oid
+	})
+	for _, key := range keys {
+		listFn(key.oid)
+	}
+	return nil
+}
+
+// GetXattr gets the xattr with key name of the object with key oid. It
+// returns the length of the value.
+func (ioctx *IOContext) GetXattr(oid, name string, data []byte) (int, error) {
+	n := 0
+	err := ioctx.apply(oid, []step{func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		v, ok := o.xattrs[name]
+		switch {
+		case !ok:
+			return errNoData
+		case len(v) > len(data):
+			return errRange
+		}
+		n = copy(data, v)
+		return nil
+	}}, false)
+	return n, err
+}
+
+// SetXattr sets the xattr with key name of the object with key oid.
+func (ioctx *IOContext) SetXattr(oid, name string, data []byte) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.SetXattr(name, data) })
+}
+
+// ListXattrs lists all the xattrs of the object with key oid.
+func (ioctx *IOContext) ListXattrs(oid string) (map[string][]byte, error) {
+	m := map[string][]byte{}
+	err := ioctx.apply(oid, []step{func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		for k, v := range o.xattrs {
+			m[k] = append([]byte(nil), v...)
+		}
+		return nil
+	}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RmXattr removes the xattr with key name from the object with key oid.
+func (ioctx *IOContext) RmXattr(oid, name string) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.RmXattr(name) })
+}
+
+// SetOmap sets the key-value pairs in the omap of the object with key oid.
+func (ioctx *IOContext) SetOmap(oid string, pairs map[string][]byte) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.SetOmap(pairs) })
+}
+
+// ListOmapValues calls listFn for up to maxReturn omap key-value pairs of
+// the object with key oid, with keys greater than startAfter and starting
+// with filterPrefix.
+func (ioctx *IOContext) ListOmapValues(oid, startAfter, filterPrefix string, maxReturn int64, listFn api.OmapListFunc) error {
+	r := &ReadOp{ioctx: ioctx}
+	it := r.GetOmapValues(startAfter, filterPrefix, uint64(maxReturn))
+	if err := operationError(r.Operate(oid, api.OperationNoFlag)); err != nil {
+		return err
+	}
+	for {
+		kv, err := it.Next()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			return nil
+		}
+		listFn(kv.Key, kv.Value)
+	}
+}
+
+// GetOmapValues returns up to maxReturn omap key-value pairs of the object
+// with key oid, with keys greater than startAfter and starting with
+// filterPrefix.
+func (ioctx *IOContext) GetOmapValues(oid, startAfter, filterPrefix string, maxReturn int64) (map[string][]byte, error) {
+	m := map[string][]byte{}
+	err := ioctx.ListOmapValues(oid, startAfter, filterPrefix, maxReturn,
+		func(key string, value []byte) {
+			m[key] = value
+		})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// GetAllOmapValues returns all omap key-value pairs of the object with key
+// oid, with keys greater than startAfter and starting with filterPrefix.
+func (ioctx *IOContext) GetAllOmapValues(oid, startAfter, filterPrefix string, iteratorSize int64) (map[string][]byte, error) {
+	omap := map[string][]byte{}
+	omapSize := 0
+	for {
+		err := ioctx.ListOmapValues(oid, startAfter, filterPrefix, iteratorSize,
+			func(key string, value []byte) {
+				omap[key] = value
+				startAfter = key
+			})
+		if err != nil {
+			return omap, err
+		}
+		if len(omap) == omapSize {
+			return omap, nil
+		}
+		omapSize = len(omap)
+	}
+}
+
+// RmOmapKeys removes the given keys from the omap of the object with key
+// oid.
+func (ioctx *IOContext) RmOmapKeys(oid string, keys []string) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.RmOmapKeys(keys) })
+}
+
+// CleanOmap removes all keys from the omap of the object with key oid.
+func (ioctx *IOContext) CleanOmap(oid string) error {
+	return ioctx.write(oid, func(w *WriteOp) { w.CleanOmap() })
+}
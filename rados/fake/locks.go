@@ -0,0 +1,212 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+// the lock flags defined by librados
+const (
+	lockFlagMayRenew  = 0x1
+	lockFlagMustRenew = 0x2
+)
+
+type lock struct {
+	exclusive bool
+	tag       string
+	holders   map[lockerKey]locker
+}
+
+type lockerKey struct {
+	client string
+	cookie string
+}
+
+type locker struct {
+	addr    string
+	desc    string
+	expires time.Time // zero if the lock does not expire
+}
+
+func (l *lock) clone() *lock {
+	c := &lock{
+		exclusive: l.exclusive,
+		tag:       l.tag,
+		holders:   make(map[lockerKey]locker, len(l.holders)),
+	}
+	for k, v := range l.holders {
+		c.holders[k] = v
+	}
+	return c
+}
+
+// expire removes the holders whose lock has expired.
+func (l *lock) expire(now time.Time) {
+	for k, h := range l.holders {
+		if !h.expires.IsZero() && !now.Before(h.expires) {
+			delete(l.holders, k)
+		}
+	}
+}
+
+func (ioctx *IOContext) lock(
+	oid, name, cookie, tag, desc string, duration time.Duration,
+	flags *byte, exclusive bool) (int, error) {
+
+	var f byte
+	if flags != nil {
+		f = *flags
+	}
+	key := lockerKey{client: ioctx.conn.client, cookie: cookie}
+	ret := 0
+	err := ioctx.apply(oid, []step{func(s *objectState) error {
+		now := time.Now()
+		holder := locker{addr: ioctx.conn.addr, desc: desc}
+		if duration > 0 {
+			holder.expires = now.Add(duration)
+		}
+
+		var l *lock
+		if s.obj != nil {
+			l = s.obj.locks[name]
+		}
+		if l != nil {
+			l.expire(now)
+		}
+		switch {
+		case l == nil || len(l.holders) == 0:
+			if f&lockFlagMustRenew != 0 {
+				ret = retNotFound
+				return nil
+			}
+			l = &lock{holders: map[lockerKey]locker{}}
+		case hasHolder(l, key):
+			if f&(lockFlagMayRenew|lockFlagMustRenew) == 0 {
+				ret = retExists
+				return nil
+			}
+		case f&lockFlagMustRenew != 0:
+			ret = retNotFound
+			return nil
+		case exclusive || l.exclusive || l.tag != tag:
+			ret = retBusy
+			return nil
+		}
+		l.exclusive = exclusive
+		l.tag = tag
+		l.holders[key] = holder
+		// taking a lock creates the object
+		s.create().locks[name] = l
+		return nil
+	}}, true)
+	if err != nil {
+		return 0, err
+	}
+	return ret, nil
+}
+
+func hasHolder(l *lock, key lockerKey) bool {
+	_, ok := l.holders[key]
+	return ok
+}
+
+// LockExclusive takes an exclusive lock on an object. It returns -EBUSY if
+// the lock is held by another (client, cookie) pair and -EEXIST if it is
+// already held by the same pair.
+func (ioctx *IOContext) LockExclusive(oid, name, cookie, desc string, duration time.Duration, flags *byte) (int, error) {
+	return ioctx.lock(oid, name, cookie, "", desc, duration, flags, true)
+}
+
+// LockShared takes a shared lock on an object. It returns -EBUSY if the
+// lock is held exclusively or with another tag and -EEXIST if it is already
+// held by the same (client, cookie) pair.
+func (ioctx *IOContext) LockShared(oid, name, cookie, tag, desc string, duration time.Duration, flags *byte) (int, error) {
+	return ioctx.lock(oid, name, cookie, tag, desc, duration, flags, false)
+}
+
+// Unlock releases a shared or exclusive lock on an object. It returns
+// -ENOENT if the lock is not held by the (client, cookie) pair.
+func (ioctx *IOContext) Unlock(oid, name, cookie string) (int, error) {
+	return ioctx.BreakLock(oid, name, ioctx.conn.client, cookie)
+}
+
+// BreakLock releases a shared or exclusive lock on an object, which was
+// taken by the specified client. It returns -ENOENT if the lock is not held
+// by the (client, cookie) pair.
+func (ioctx *IOContext) BreakLock(oid, name, client, cookie string) (int, error) {
+	key := lockerKey{client: client, cookie: cookie}
+	ret := 0
+	err := ioctx.apply(oid, []step{func(s *objectState) error {
+		var l *lock
+		if s.obj != nil {
+			l = s.obj.locks[name]
+		}
+		if l != nil {
+			l.expire(time.Now())
+		}
+		if l == nil || !hasHolder(l, key) {
+			ret = retNotFound
+			return nil
+		}
+		delete(l.holders, key)
+		if len(l.holders) == 0 {
+			delete(s.obj.locks, name)
+		}
+		s.dirty = true
+		return nil
+	}}, true)
+	if err != nil {
+		return 0, err
+	}
+	return ret, nil
+}
+
+// ListLockers lists the clients that hold the named lock on an object.
+func (ioctx *IOContext) ListLockers(oid, name string) (*api.LockInfo, error) {
+	info := &api.LockInfo{
+		Clients: []string{},
+		Cookies: []string{},
+		Addrs:   []string{},
+	}
+	err := ioctx.apply(oid, []step{func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		l, ok := o.locks[name]
+		if !ok {
+			return nil
+		}
+		now := time.Now()
+		keys := []lockerKey{}
+		for k, h := range l.holders {
+			if h.expires.IsZero() || now.Before(h.expires) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].client != keys[j].client {
+				return keys[i].client < keys[j].client
+			}
+			return keys[i].cookie < keys[j].cookie
+		})
+		for _, k := range keys {
+			info.Clients = append(info.Clients, k.client)
+			info.Cookies = append(info.Cookies, k.cookie)
+			info.Addrs = append(info.Addrs, l.holders[k].addr)
+		}
+		info.NumLockers = len(keys)
+		info.Exclusive = l.exclusive
+		info.Tag = l.tag
+		return nil
+	}}, false)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
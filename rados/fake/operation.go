@@ -0,0 +1,406 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package fake
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+// objectState is the state of an object while the steps of an operation are
+// applied to it.
+type objectState struct {
+	obj   *object // nil if the object does not exist
+	dirty bool
+}
+
+func (s *objectState) existing() (*object, error) {
+	if s.obj == nil {
+		return nil, api.ErrNotFound
+	}
+	return s.obj, nil
+}
+
+func (s *objectState) create() *object {
+	if s.obj == nil {
+		s.obj = newObject()
+	}
+	s.dirty = true
+	return s.obj
+}
+
+type step func(s *objectState) error
+
+// apply applies the steps to the object with key oid atomically. The steps
+// of a write operation work on a copy of the object that replaces the
+// object if all steps succeed.
+func (ioctx *IOContext) apply(oid string, steps []step, write bool) error {
+	c := ioctx.conn.cluster
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	key := objectKey{namespace: ioctx.namespace, oid: oid}
+	s := &objectState{obj: ioctx.pool.objects[key]}
+	if write && s.obj != nil {
+		s.obj = s.obj.clone()
+	}
+	for _, st := range steps {
+		if err := st(s); err != nil {
+			return err
+		}
+	}
+
+	if write && s.dirty {
+		if s.obj == nil {
+			delete(ioctx.pool.objects, key)
+		} else {
+			ioctx.pool.version++
+			s.obj.version = ioctx.pool.version
+			s.obj.mtime = time.Now().Truncate(time.Second)
+			ioctx.pool.objects[key] = s.obj
+		}
+	}
+	if s.obj != nil {
+		ioctx.lastVersion = s.obj.version
+	}
+	return nil
+}
+
+func assertVersion(s *objectState, ver uint64) error {
+	o, err := s.existing()
+	switch {
+	case err != nil:
+		return err
+	case ver < o.version:
+		return errRange
+	case ver > o.version:
+		return errOverflow
+	}
+	return nil
+}
+
+// WriteOp is the in-memory implementation of api.WriteOperation. The
+// steps of the operation are applied atomically by Operate.
+type WriteOp struct {
+	ioctx *IOContext
+	steps []step
+}
+
+var _ api.WriteOperation = (*WriteOp)(nil)
+
+// NewWriteOperation returns a new WriteOp bound to the I/O context.
+func (ioctx *IOContext) NewWriteOperation() api.WriteOperation {
+	return &WriteOp{ioctx: ioctx}
+}
+
+func (w *WriteOp) add(st step) {
+	w.steps = append(w.steps, st)
+}
+
+// Create a rados object.
+func (w *WriteOp) Create(exclusive api.CreateOption) {
+	w.add(func(s *objectState) error {
+		if s.obj != nil {
+			if exclusive == api.CreateExclusive {
+				return api.ErrObjectExists
+			}
+			return nil
+		}
+		s.create()
+		return nil
+	})
+}
+
+// Remove the object.
+func (w *WriteOp) Remove() {
+	w.add(func(s *objectState) error {
+		if _, err := s.existing(); err != nil {
+			return err
+		}
+		s.obj = nil
+		s.dirty = true
+		return nil
+	})
+}
+
+// Write writes the given bytes to the object at the given offset.
+func (w *WriteOp) Write(b []byte, offset uint64) {
+	b = append([]byte(nil), b...)
+	w.add(func(s *objectState) error {
+		o := s.create()
+		end := int(offset) + len(b)
+		if end > len(o.data) {
+			o.data = append(o.data, make([]byte, end-len(o.data))...)
+		}
+		copy(o.data[offset:], b)
+		return nil
+	})
+}
+
+// WriteFull writes the given bytes as the whole object, replacing it.
+func (w *WriteOp) WriteFull(b []byte) {
+	b = append([]byte(nil), b...)
+	w.add(func(s *objectState) error {
+		s.create().data = b
+		return nil
+	})
+}
+
+// Append the given bytes to the object.
+func (w *WriteOp) Append(b []byte) {
+	b = append([]byte(nil), b...)
+	w.add(func(s *objectState) error {
+		o := s.create()
+		o.data = append(o.data, b...)
+		return nil
+	})
+}
+
+// Truncate resizes the object to the given size.
+func (w *WriteOp) Truncate(offset uint64) {
+	w.add(func(s *objectState) error {
+		o := s.create()
+		if int(offset) <= len(o.data) {
+			o.data = o.data[:offset]
+		} else {
+			o.data = append(o.data, make([]byte, int(offset)-len(o.data))...)
+		}
+		return nil
+	})
+}
+
+// SetXattr sets an xattr.
+func (w *WriteOp) SetXattr(name string, value []byte) {
+	value = append([]byte(nil), value...)
+	w.add(func(s *objectState) error {
+		s.create().xattrs[name] = value
+		return nil
+	})
+}
+
+// RmXattr removes the xattr with key name from the object.
+func (w *WriteOp) RmXattr(name string) {
+	w.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		delete(o.xattrs, name)
+		s.dirty = true
+		return nil
+	})
+}
+
+// SetOmap sets the given key-value pairs in the omap of the object.
+func (w *WriteOp) SetOmap(pairs map[string][]byte) {
+	m := make(map[string][]byte, len(pairs))
+	for k, v := range pairs {
+		m[k] = append([]byte(nil), v...)
+	}
+	w.add(func(s *objectState) error {
+		o := s.create()
+		for k, v := range m {
+			o.omap[k] = v
+		}
+		return nil
+	})
+}
+
+// RmOmapKeys removes the given keys from the omap of the object.
+func (w *WriteOp) RmOmapKeys(keys []string) {
+	keys = append([]string(nil), keys...)
+	w.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		for _, k := range keys {
+			delete(o.omap, k)
+		}
+		s.dirty = true
+		return nil
+	})
+}
+
+// CleanOmap removes all keys from the omap of the object.
+func (w *WriteOp) CleanOmap() {
+	w.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		o.omap = map[string][]byte{}
+		s.dirty = true
+		return nil
+	})
+}
+
+// AssertExists assures the object targeted by the write op exists.
+func (w *WriteOp) AssertExists() {
+	w.add(func(s *objectState) error {
+		_, err := s.existing()
+		return err
+	})
+}
+
+// AssertVersion ensures that the object exists and that its version is
+// equal to ver.
+func (w *WriteOp) AssertVersion(ver uint64) {
+	w.add(func(s *objectState) error {
+		return assertVersion(s, ver)
+	})
+}
+
+// Operate performs the steps of the operation on the object with key oid.
+// No changes are made if any step fails.
+func (w *WriteOp) Operate(oid string, flags api.OperationFlags) error {
+	if err := w.ioctx.apply(oid, w.steps, true); err != nil {
+		return api.OperationError{OpError: err}
+	}
+	return nil
+}
+
+// Release the resources associated with the operation.
+func (w *WriteOp) Release() {
+	w.steps = nil
+}
+
+// ReadOp is the in-memory implementation of api.ReadOperation. The
+// results of the steps are valid after Operate is called.
+type ReadOp struct {
+	ioctx *IOContext
+	steps []step
+}
+
+var _ api.ReadOperation = (*ReadOp)(nil)
+
+// NewReadOperation returns a new ReadOp bound to the I/O context.
+func (ioctx *IOContext) NewReadOperation() api.ReadOperation {
+	return &ReadOp{ioctx: ioctx}
+}
+
+func (r *ReadOp) add(st step) {
+	r.steps = append(r.steps, st)
+}
+
+// AssertExists assures the object targeted by the read op exists.
+func (r *ReadOp) AssertExists() {
+	r.add(func(s *objectState) error {
+		_, err := s.existing()
+		return err
+	})
+}
+
+// AssertVersion ensures that the object exists and that its version is
+// equal to ver.
+func (r *ReadOp) AssertVersion(ver uint64) {
+	r.add(func(s *objectState) error {
+		return assertVersion(s, ver)
+	})
+}
+
+// Read bytes from offset into buffer.
+func (r *ReadOp) Read(offset uint64, buffer []byte) *api.ReadOpReadStep {
+	rs := &api.ReadOpReadStep{}
+	r.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		n := 0
+		if int(offset) < len(o.data) {
+			n = copy(buffer, o.data[offset:])
+		}
+		rs.BytesRead = int64(n)
+		return nil
+	})
+	return rs
+}
+
+// Stat gets the size and the last modification time of the object.
+func (r *ReadOp) Stat() *api.ReadOpStatStep {
+	ss := &api.ReadOpStatStep{}
+	r.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		ss.Size = uint64(len(o.data))
+		ss.ModTime = o.mtime
+		return nil
+	})
+	return ss
+}
+
+// omapIterator is the in-memory implementation of api.OmapIterator.
+type omapIterator struct {
+	pairs      []api.OmapKeyValue
+	more       bool
+	canIterate bool
+}
+
+// Next returns the next key value pair or nil if iteration is exhausted.
+func (it *omapIterator) Next() (*api.OmapKeyValue, error) {
+	if !it.canIterate {
+		return nil, api.ErrOperationIncomplete
+	}
+	if len(it.pairs) == 0 {
+		return nil, nil
+	}
+	kv := it.pairs[0]
+	it.pairs = it.pairs[1:]
+	return &kv, nil
+}
+
+// More returns true if there are more matching keys available.
+func (it *omapIterator) More() bool {
+	return it.more
+}
+
+// GetOmapValues is used to iterate over a set, or sub-set, of omap keys.
+func (r *ReadOp) GetOmapValues(startAfter, filterPrefix string, maxReturn uint64) api.OmapIterator {
+	it := &omapIterator{}
+	r.add(func(s *objectState) error {
+		o, err := s.existing()
+		if err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(o.omap))
+		for k := range o.omap {
+			if k > startAfter && strings.HasPrefix(k, filterPrefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		if uint64(len(keys)) > maxReturn {
+			keys = keys[:maxReturn]
+			it.more = true
+		}
+		for _, k := range keys {
+			it.pairs = append(it.pairs, api.OmapKeyValue{
+				Key:   k,
+				Value: append([]byte(nil), o.omap[k]...),
+			})
+		}
+		it.canIterate = true
+		return nil
+	})
+	return it
+}
+
+// Operate performs the steps of the operation on the object with key oid.
+func (r *ReadOp) Operate(oid string, flags api.OperationFlags) error {
+	if err := r.ioctx.apply(oid, r.steps, false); err != nil {
+		return api.OperationError{OpError: err}
+	}
+	return nil
+}
+
+// Release the resources associated with the operation.
+func (r *ReadOp) Release() {
+	r.steps = nil
+}
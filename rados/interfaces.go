@@ -0,0 +1,86 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+var _ api.IOContextAPI = (*IOContext)(nil)
+
+// ioctxWriteOp binds a WriteOp to an IOContext.
+type ioctxWriteOp struct {
+	*WriteOp
+	ioctx *IOContext
+}
+
+// NewWriteOperation returns a WriteOp, bound to the I/O context, as an
+// api.WriteOperation.
+func (ioctx *IOContext) NewWriteOperation() api.WriteOperation {
+	return &ioctxWriteOp{CreateWriteOp(), ioctx}
+}
+
+// Operate will perform the operation(s) on the object with key oid.
+func (w *ioctxWriteOp) Operate(oid string, flags OperationFlags) error {
+	return w.WriteOp.Operate(w.ioctx, oid, flags)
+}
+
+// ioctxReadOp binds a ReadOp to an IOContext.
+type ioctxReadOp struct {
+	*ReadOp
+	ioctx *IOContext
+}
+
+// NewReadOperation returns a ReadOp, bound to the I/O context, as an
+// api.ReadOperation.
+func (ioctx *IOContext) NewReadOperation() api.ReadOperation {
+	return &ioctxReadOp{CreateReadOp(), ioctx}
+}
+
+// resultStep copies the results of a step to the corresponding type of the
+// api package when the operation is updated.
+type resultStep struct {
+	withoutFree
+	copy func()
+}
+
+func (s *resultStep) update() error {
+	s.copy()
+	return nil
+}
+
+// Read bytes from offset into buffer as part of the read operation.
+func (r *ioctxReadOp) Read(offset uint64, buffer []byte) *api.ReadOpReadStep {
+	step := r.ReadOp.Read(offset, buffer)
+	res := &api.ReadOpReadStep{}
+	r.steps = append(r.steps, &resultStep{copy: func() {
+		res.BytesRead = step.BytesRead
+		res.Result = step.Result
+	}})
+	return res
+}
+
+// Stat gets the size and the last modification time of the object as part
+// of the read operation.
+func (r *ioctxReadOp) Stat() *api.ReadOpStatStep {
+	step := r.ReadOp.Stat()
+	res := &api.ReadOpStatStep{}
+	r.steps = append(r.steps, &resultStep{copy: func() {
+		res.Size = step.Size
+		res.ModTime = step.ModTime
+		res.Result = step.Result
+	}})
+	return res
+}
+
+// GetOmapValues is used to iterate over a set, or sub-set, of omap keys as
+// part of the read operation.
+func (r *ioctxReadOp) GetOmapValues(startAfter, filterPrefix string, maxReturn uint64) api.OmapIterator {
+	return r.ReadOp.GetOmapValues(startAfter, filterPrefix, maxReturn)
+}
+
+// Operate will perform the operation(s) on the object with key oid.
+func (r *ioctxReadOp) Operate(oid string, flags OperationFlags) error {
+	return r.ReadOp.Operate(r.ioctx, oid, flags)
+}
@@ -0,0 +1,78 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/rados/api"
+)
+
+func TestAPIConstants(t *testing.T) {
+	// the api package can not use the constants of librados
+	assert.EqualValues(t, CreateExclusive, api.CreateExclusive)
+	assert.EqualValues(t, CreateIdempotent, api.CreateIdempotent)
+	assert.Equal(t, AllNamespaces, api.AllNamespaces)
+	assert.Equal(t, OperationNoFlag, api.OperationNoFlag)
+	assert.Equal(t, OperationBalanceReads, api.OperationBalanceReads)
+	assert.Equal(t, OperationLocalizeReads, api.OperationLocalizeReads)
+	assert.Equal(t, OperationOrderReadsWrites, api.OperationOrderReadsWrites)
+	assert.Equal(t, OperationIgnoreCache, api.OperationIgnoreCache)
+	assert.Equal(t, OperationSkipRWLocks, api.OperationSkipRWLocks)
+	assert.Equal(t, OperationIgnoreOverlay, api.OperationIgnoreOverlay)
+	assert.Equal(t, OperationFullTry, api.OperationFullTry)
+	assert.Equal(t, OperationFullForce, api.OperationFullForce)
+	assert.Equal(t, OperationIgnoreRedirect, api.OperationIgnoreRedirect)
+	assert.Equal(t, OperationOrderSnap, api.OperationOrderSnap)
+
+	// the errors are the same
+	assert.Equal(t, ErrNotFound, api.ErrNotFound)
+	assert.Equal(t, ErrPermissionDenied, api.ErrPermissionDenied)
+	assert.Equal(t, ErrObjectExists, api.ErrObjectExists)
+	assert.Equal(t, ErrOperationIncomplete, api.ErrOperationIncomplete)
+}
+
+func (suite *RadosTestSuite) TestIOContextAPIOperations() {
+	suite.SetupConnection()
+	var ioctx api.IOContextAPI = suite.ioctx
+
+	oid := suite.GenObjectName()
+	w := ioctx.NewWriteOperation()
+	defer w.Release()
+	w.Create(CreateExclusive)
+	w.WriteFull([]byte("hello"))
+	w.SetOmap(map[string][]byte{"k": []byte("v")})
+	err := w.Operate(oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+	ver, err := ioctx.GetLastVersion()
+	require.NoError(suite.T(), err)
+
+	r := ioctx.NewReadOperation()
+	defer r.Release()
+	r.AssertVersion(ver)
+	buf := make([]byte, 8)
+	rs := r.Read(0, buf)
+	ss := r.Stat()
+	it := r.GetOmapValues("", "", 10)
+	err = r.Operate(oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "hello", string(buf[:rs.BytesRead]))
+	assert.EqualValues(suite.T(), 5, ss.Size)
+	kv, err := it.Next()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "k", kv.Key)
+	assert.False(suite.T(), it.More())
+
+	w2 := ioctx.NewWriteOperation()
+	defer w2.Release()
+	w2.AssertVersion(ver - 1)
+	w2.Remove()
+	err = w2.Operate(oid, OperationNoFlag)
+	assert.Error(suite.T(), err)
+	_, err = ioctx.Stat(oid)
+	assert.NoError(suite.T(), err)
+}
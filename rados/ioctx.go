@@ -29,12 +29,13 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/ceph/go-ceph/internal/radostypes"
 	"github.com/ceph/go-ceph/internal/retry"
 )
 
 // CreateOption is passed to IOContext.Create() and should be one of
 // CreateExclusive or CreateIdempotent.
-type CreateOption int
+type CreateOption = radostypes.CreateOption
 
 const (
 	// CreateExclusive if used with IOContext.Create() and the object
@@ -79,22 +80,10 @@ type PoolStat struct {
 //revive:enable:var-naming
 
 // ObjectStat represents an object stat information
-type ObjectStat struct {
-	// current length in bytes
-	Size uint64
-	// last modification time
-	ModTime time.Time
-}
+type ObjectStat = radostypes.ObjectStat
 
 // LockInfo represents information on a current Ceph lock
-type LockInfo struct {
-	NumLockers int
-	Exclusive  bool
-	Tag        string
-	Clients    []string
-	Cookies    []string
-	Addrs      []string
-}
+type LockInfo = radostypes.LockInfo
 
 // IOContext represents a context for performing I/O within a pool.
 type IOContext struct {
@@ -104,6 +93,10 @@ type IOContext struct {
 	// that Go's GC doesn't trigger the Conn's finalizer before this
 	// IOContext is destroyed.
 	conn *Conn
+
+	// observer, if set, is informed about the I/O operations performed with
+	// the IOContext.
+	observer *ioctxObserver
 }
 
 // validate returns an error if the ioctx is not ready to be used
@@ -135,6 +128,9 @@ func (ioctx *IOContext) SetNamespace(namespace string) {
 		defer C.free(unsafe.Pointer(cns))
 	}
 	C.rados_ioctx_set_namespace(ioctx.ioctx, cns)
+	if ioctx.observer != nil {
+		ioctx.observer.namespace = namespace
+	}
 }
 
 // Create a new object with key oid.
@@ -152,7 +148,13 @@ func (ioctx *IOContext) Create(oid string, exclusive CreateOption) error {
 
 // Write writes len(data) bytes to the object with key oid starting at byte
 // offset offset. It returns an error, if any.
-func (ioctx *IOContext) Write(oid string, data []byte, offset uint64) error {
+func (ioctx *IOContext) Write(oid string, data []byte, offset uint64) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Write", oid, len(data), start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -172,7 +174,13 @@ func (ioctx *IOContext) Write(oid string, data []byte, offset uint64) error {
 // WriteFull writes len(data) bytes to the object with key oid.
 // The object is filled with the provided data. If the object exists,
 // it is atomically truncated and then written. It returns an error, if any.
-func (ioctx *IOContext) WriteFull(oid string, data []byte) error {
+func (ioctx *IOContext) WriteFull(oid string, data []byte) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.WriteFull", oid, len(data), start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -185,7 +193,13 @@ func (ioctx *IOContext) WriteFull(oid string, data []byte) error {
 // Append appends len(data) bytes to the object with key oid.
 // The object is appended with the provided data. If the object exists,
 // it is atomically appended to. It returns an error, if any.
-func (ioctx *IOContext) Append(oid string, data []byte) error {
+func (ioctx *IOContext) Append(oid string, data []byte) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Append", oid, len(data), start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -197,7 +211,13 @@ func (ioctx *IOContext) Append(oid string, data []byte) error {
 
 // Read reads up to len(data) bytes from the object with key oid starting at byte
 // offset offset. It returns the number of bytes read and an error, if any.
-func (ioctx *IOContext) Read(oid string, data []byte, offset uint64) (int, error) {
+func (ioctx *IOContext) Read(oid string, data []byte, offset uint64) (n int, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Read", oid, n, start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -220,7 +240,13 @@ func (ioctx *IOContext) Read(oid string, data []byte, offset uint64) (int, error
 }
 
 // Delete deletes the object with key oid. It returns an error, if any.
-func (ioctx *IOContext) Delete(oid string) error {
+func (ioctx *IOContext) Delete(oid string) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Delete", oid, 0, start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -231,7 +257,13 @@ func (ioctx *IOContext) Delete(oid string) error {
 // enlarges the object, the new area is logically filled with zeroes. If the
 // operation shrinks the object, the excess data is removed. It returns an
 // error, if any.
-func (ioctx *IOContext) Truncate(oid string, size uint64) error {
+func (ioctx *IOContext) Truncate(oid string, size uint64) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Truncate", oid, 0, start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -308,7 +340,7 @@ func (ioctx *IOContext) GetPoolName() (name string, err error) {
 
 // ObjectListFunc is the type of the function called for each object visited
 // by ListObjects.
-type ObjectListFunc func(oid string)
+type ObjectListFunc = radostypes.ObjectListFunc
 
 // ListObjects lists all of the objects in the pool associated with the I/O
 // context, and called the provided listFn function for each object, passing
@@ -353,6 +385,12 @@ func (ioctx *IOContext) ListObjects(listFn ObjectListFunc) error {
 
 // Stat returns the size of the object and its last modification time
 func (ioctx *IOContext) Stat(object string) (stat ObjectStat, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.Stat", object, 0, start, err)
+		}(time.Now())
+	}
+
 	var cPsize C.uint64_t
 	var cPmtime C.time_t
 	cObject := C.CString(object)
@@ -375,7 +413,13 @@ func (ioctx *IOContext) Stat(object string) (stat ObjectStat, err error) {
 
 // GetXattr gets an xattr with key `name`, it returns the length of
 // the key read or an error if not successful
-func (ioctx *IOContext) GetXattr(object string, name string, data []byte) (int, error) {
+func (ioctx *IOContext) GetXattr(object string, name string, data []byte) (n int, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.GetXattr", object, n, start, err)
+		}(time.Now())
+	}
+
 	cObject := C.CString(object)
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cObject))
@@ -395,7 +439,13 @@ func (ioctx *IOContext) GetXattr(object string, name string, data []byte) (int,
 }
 
 // SetXattr sets an xattr for an object with key `name` with value as `data`
-func (ioctx *IOContext) SetXattr(object string, name string, data []byte) error {
+func (ioctx *IOContext) SetXattr(object string, name string, data []byte) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.SetXattr", object, len(data), start, err)
+		}(time.Now())
+	}
+
 	cObject := C.CString(object)
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(cObject))
@@ -413,7 +463,13 @@ func (ioctx *IOContext) SetXattr(object string, name string, data []byte) error
 
 // ListXattrs lists all the xattrs for an object. The xattrs are returned as a
 // mapping of string keys and byte-slice values.
-func (ioctx *IOContext) ListXattrs(oid string) (map[string][]byte, error) {
+func (ioctx *IOContext) ListXattrs(oid string) (_ map[string][]byte, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.ListXattrs", oid, 0, start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	defer C.free(unsafe.Pointer(coid))
 
@@ -445,7 +501,13 @@ func (ioctx *IOContext) ListXattrs(oid string) (map[string][]byte, error) {
 }
 
 // RmXattr removes an xattr with key `name` from object `oid`
-func (ioctx *IOContext) RmXattr(oid string, name string) error {
+func (ioctx *IOContext) RmXattr(oid string, name string) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.RmXattr", oid, 0, start, err)
+		}(time.Now())
+	}
+
 	coid := C.CString(oid)
 	cName := C.CString(name)
 	defer C.free(unsafe.Pointer(coid))
@@ -642,7 +704,14 @@ func (ioctx *IOContext) ListLockers(oid, name string) (*LockInfo, error) {
 	if ret < 0 {
 		return nil, radosError(ret)
 	}
-	return &LockInfo{int(ret), cExclusive == 1, C.GoString(cTag), splitCString(cClients, cClientsLen), splitCString(cCookies, cCookiesLen), splitCString(cAddrs, cAddrsLen)}, nil
+	return &LockInfo{
+		NumLockers: int(ret),
+		Exclusive:  cExclusive == 1,
+		Tag:        C.GoString(cTag),
+		Clients:    splitCString(cClients, cClientsLen),
+		Cookies:    splitCString(cCookies, cCookiesLen),
+		Addrs:      splitCString(cAddrs, cAddrsLen),
+	}, nil
 }
 
 // BreakLock releases a shared or exclusive lock on an object, which was taken by the specified client.
@@ -0,0 +1,233 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
+)
+
+// AioWrite asynchronously writes len(data) bytes to the object with key oid
+// starting at byte offset offset. The data is copied before AioWrite returns.
+//
+// Implements:
+//
+//	int rados_aio_write(rados_ioctx_t io, const char *oid,
+//	                    rados_completion_t completion,
+//	                    const char *buf, size_t len, uint64_t off);
+func (ioctx *IOContext) AioWrite(oid string, data []byte, offset uint64) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+	var buf *C.char
+	if len(data) > 0 {
+		buf = (*C.char)(unsafe.Pointer(&data[0]))
+	}
+
+	ret := C.rados_aio_write(
+		ioctx.ioctx,
+		coid,
+		comp.c,
+		buf,
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	return comp.submitted(ret)
+}
+
+// AioWriteFull asynchronously writes len(data) bytes to the object with key
+// oid, atomically replacing the contents of the object. The data is copied
+// before AioWriteFull returns.
+//
+// Implements:
+//
+//	int rados_aio_write_full(rados_ioctx_t io, const char *oid,
+//	                         rados_completion_t completion,
+//	                         const char *buf, size_t len);
+func (ioctx *IOContext) AioWriteFull(oid string, data []byte) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+	var buf *C.char
+	if len(data) > 0 {
+		buf = (*C.char)(unsafe.Pointer(&data[0]))
+	}
+
+	ret := C.rados_aio_write_full(
+		ioctx.ioctx,
+		coid,
+		comp.c,
+		buf,
+		C.size_t(len(data)))
+	return comp.submitted(ret)
+}
+
+// AioAppend asynchronously appends len(data) bytes to the object with key
+// oid. The data is copied before AioAppend returns.
+//
+// Implements:
+//
+//	int rados_aio_append(rados_ioctx_t io, const char *oid,
+//	                     rados_completion_t completion,
+//	                     const char *buf, size_t len);
+func (ioctx *IOContext) AioAppend(oid string, data []byte) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+	var buf *C.char
+	if len(data) > 0 {
+		buf = (*C.char)(unsafe.Pointer(&data[0]))
+	}
+
+	ret := C.rados_aio_append(
+		ioctx.ioctx,
+		coid,
+		comp.c,
+		buf,
+		C.size_t(len(data)))
+	return comp.submitted(ret)
+}
+
+// AioRead asynchronously reads up to len(data) bytes from the object with key
+// oid starting at byte offset offset. The data slice must not be accessed
+// until the returned Completion is complete. The number of bytes read is
+// returned by the Completion's Result method.
+//
+// Implements:
+//
+//	int rados_aio_read(rados_ioctx_t io, const char *oid,
+//	                   rados_completion_t completion,
+//	                   char *buf, size_t len, uint64_t off);
+func (ioctx *IOContext) AioRead(oid string, data []byte, offset uint64) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyArgument
+	}
+
+	// librados writes to the buffer after this call returns, so we have to
+	// use C memory and copy the result to the Go buffer on completion
+	cBuf := C.malloc(C.size_t(len(data)))
+	comp, err := newCompletion(
+		func(ret C.int) error {
+			if ret > 0 {
+				cutil.Memcpy(
+					cutil.CPtr(unsafe.Pointer(&data[0])),
+					cutil.CPtr(cBuf),
+					cutil.SizeT(ret))
+			}
+			return getErrorIfNegative(ret)
+		},
+		func() { C.free(cBuf) })
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+
+	ret := C.rados_aio_read(
+		ioctx.ioctx,
+		coid,
+		comp.c,
+		(*C.char)(cBuf),
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	return comp.submitted(ret)
+}
+
+// AioRemove asynchronously deletes the object with key oid.
+//
+// Implements:
+//
+//	int rados_aio_remove(rados_ioctx_t io, const char *oid,
+//	                     rados_completion_t completion);
+func (ioctx *IOContext) AioRemove(oid string) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+
+	ret := C.rados_aio_remove(ioctx.ioctx, coid, comp.c)
+	return comp.submitted(ret)
+}
+
+// AioStat asynchronously gets the size and the last modification time of the
+// object with key oid. The stat value is filled in when the returned
+// Completion is complete and must not be accessed before.
+//
+// Implements:
+//
+//	int rados_aio_stat(rados_ioctx_t io, const char *o,
+//	                   rados_completion_t completion,
+//	                   uint64_t *psize, time_t *pmtime);
+func (ioctx *IOContext) AioStat(oid string, stat *ObjectStat) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	if stat == nil {
+		return nil, ErrEmptyArgument
+	}
+
+	cSize := (*C.uint64_t)(C.malloc(C.sizeof_uint64_t))
+	cMtime := (*C.time_t)(C.malloc(C.sizeof_time_t))
+	comp, err := newCompletion(
+		func(ret C.int) error {
+			if ret < 0 {
+				return getError(ret)
+			}
+			*stat = ObjectStat{
+				Size:    uint64(*cSize),
+				ModTime: time.Unix(int64(*cMtime), 0),
+			}
+			return nil
+		},
+		func() {
+			C.free(unsafe.Pointer(cSize))
+			C.free(unsafe.Pointer(cMtime))
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+
+	ret := C.rados_aio_stat(ioctx.ioctx, coid, comp.c, cSize, cMtime)
+	return comp.submitted(ret)
+}
@@ -0,0 +1,146 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestAioWriteRead() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	data := []byte("input data")
+
+	comp, err := suite.ioctx.AioWrite(oid, data, 0)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	ta.True(comp.IsComplete())
+
+	out := make([]byte, 64)
+	comp, err = suite.ioctx.AioRead(oid, out, 0)
+	require.NoError(suite.T(), err)
+	<-comp.Done()
+	n, err := comp.Result()
+	ta.NoError(err)
+	ta.Equal(len(data), n)
+	ta.Equal(data, out[:n])
+
+	comp, err = suite.ioctx.AioRead(oid, out, 6)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	n, err = comp.Result()
+	ta.NoError(err)
+	ta.Equal([]byte("data"), out[:n])
+
+	suite.T().Run("emptyBuffer", func(t *testing.T) {
+		_, err := suite.ioctx.AioRead(oid, []byte{}, 0)
+		assert.Error(t, err)
+	})
+
+	suite.T().Run("notFound", func(t *testing.T) {
+		comp, err := suite.ioctx.AioRead("does-not-exist", out, 0)
+		require.NoError(t, err)
+		err = comp.Wait()
+		assert.Error(t, err)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		ioctx := &IOContext{}
+		_, err := ioctx.AioWrite(oid, data, 0)
+		assert.Equal(t, ErrInvalidIOContext, err)
+		_, err = ioctx.AioRead(oid, out, 0)
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+}
+
+func (suite *RadosTestSuite) TestAioWriteFullAppend() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	comp, err := suite.ioctx.AioWriteFull(oid, []byte("hello"))
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+
+	comp, err = suite.ioctx.AioAppend(oid, []byte(" world"))
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	v, err := comp.Version()
+	ta.NoError(err)
+	ta.NotZero(v)
+
+	out := make([]byte, 64)
+	n, err := suite.ioctx.Read(oid, out, 0)
+	ta.NoError(err)
+	ta.Equal("hello world", string(out[:n]))
+
+	comp, err = suite.ioctx.AioWriteFull(oid, []byte("bye"))
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	n, err = suite.ioctx.Read(oid, out, 0)
+	ta.NoError(err)
+	ta.Equal("bye", string(out[:n]))
+}
+
+func (suite *RadosTestSuite) TestAioStatRemove() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	data := suite.RandomBytes(128)
+	err := suite.ioctx.WriteFull(oid, data)
+	require.NoError(suite.T(), err)
+
+	var stat ObjectStat
+	comp, err := suite.ioctx.AioStat(oid, &stat)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	ta.Equal(uint64(len(data)), stat.Size)
+	ta.False(stat.ModTime.IsZero())
+
+	_, err = suite.ioctx.AioStat(oid, nil)
+	ta.Error(err)
+
+	comp, err = suite.ioctx.AioRemove(oid)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+
+	comp, err = suite.ioctx.AioStat(oid, &stat)
+	require.NoError(suite.T(), err)
+	ta.Equal(ErrNotFound, comp.Wait())
+}
+
+func (suite *RadosTestSuite) TestAioManyInFlight() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	const count = 100
+	data := []byte("some data")
+	results := make(chan error, count)
+	for i := 0; i < count; i++ {
+		comp, err := suite.ioctx.AioWriteFull(suite.GenObjectName(), data)
+		require.NoError(suite.T(), err)
+		comp.OnComplete(func(c *Completion) {
+			_, err := c.Result()
+			results <- err
+		})
+	}
+	for i := 0; i < count; i++ {
+		ta.NoError(<-results)
+	}
+
+	// registering a callback on a completed operation calls it immediately
+	comp, err := suite.ioctx.AioWriteFull(suite.GenObjectName(), data)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	called := false
+	comp.OnComplete(func(*Completion) { called = true })
+	ta.True(called)
+}
@@ -0,0 +1,238 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
+	"github.com/ceph/go-ceph/internal/retry"
+)
+
+const (
+	// ApplicationRBD is the name of the application used by RBD pools.
+	ApplicationRBD = "rbd"
+	// ApplicationCephFS is the name of the application used by CephFS pools.
+	ApplicationCephFS = "cephfs"
+	// ApplicationRGW is the name of the application used by RGW pools.
+	ApplicationRGW = "rgw"
+)
+
+// ApplicationEnable associates the application appName with the pool of the
+// IOContext. Any name may be used for custom applications. Enabling more than
+// one application on a pool fails with ErrPermissionDenied unless force is
+// true.
+//
+// Implements:
+//
+//	int rados_application_enable(rados_ioctx_t io, const char *app_name,
+//	                             int force);
+func (ioctx *IOContext) ApplicationEnable(appName string, force bool) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	cAppName := C.CString(appName)
+	defer C.free(unsafe.Pointer(cAppName))
+	cForce := C.int(0)
+	if force {
+		cForce = 1
+	}
+
+	ret := C.rados_application_enable(ioctx.ioctx, cAppName, cForce)
+	return getError(ret)
+}
+
+// ApplicationList returns the names of the applications enabled on the pool
+// of the IOContext.
+//
+// Implements:
+//
+//	int rados_application_list(rados_ioctx_t io, char *values,
+//	                           size_t *values_len);
+func (ioctx *IOContext) ApplicationList() ([]string, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+
+	var (
+		buf  []byte
+		cLen C.size_t
+		err  error
+	)
+	retry.WithSizes(1024, 1<<16, func(size int) retry.Hint {
+		// librados terminates the list with an extra NUL byte that is not
+		// included in the length it requires, so reserve one more byte
+		cLen = C.size_t(size)
+		buf = make([]byte, size+1)
+		ret := C.rados_application_list(
+			ioctx.ioctx,
+			(*C.char)(unsafe.Pointer(&buf[0])),
+			&cLen)
+		err = getError(ret)
+		return retry.Size(int(cLen)).If(err == errRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cutil.SplitSparseBuffer(buf[:cLen]), nil
+}
+
+// ApplicationMetadataGet returns the value of the metadata key of the
+// application appName on the pool of the IOContext. ErrNotFound is returned
+// if the application is not enabled or the key does not exist.
+//
+// Implements:
+//
+//	int rados_application_metadata_get(rados_ioctx_t io,
+//	                                   const char *app_name,
+//	                                   const char *key, char *value,
+//	                                   size_t *value_len);
+func (ioctx *IOContext) ApplicationMetadataGet(appName, key string) (string, error) {
+	if err := ioctx.validate(); err != nil {
+		return "", err
+	}
+
+	cAppName := C.CString(appName)
+	defer C.free(unsafe.Pointer(cAppName))
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var (
+		buf  []byte
+		cLen C.size_t
+		err  error
+	)
+	retry.WithSizes(1024, 1<<16, func(size int) retry.Hint {
+		cLen = C.size_t(size)
+		buf = make([]byte, cLen)
+		ret := C.rados_application_metadata_get(
+			ioctx.ioctx,
+			cAppName,
+			cKey,
+			(*C.char)(unsafe.Pointer(&buf[0])),
+			&cLen)
+		err = getError(ret)
+		return retry.Size(int(cLen)).If(err == errRange)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString((*C.char)(unsafe.Pointer(&buf[0]))), nil
+}
+
+// ApplicationMetadataSet sets the metadata key of the application appName on
+// the pool of the IOContext to value. ErrNotFound is returned if the
+// application is not enabled.
+//
+// Implements:
+//
+//	int rados_application_metadata_set(rados_ioctx_t io,
+//	                                   const char *app_name,
+//	                                   const char *key,
+//	                                   const char *value);
+func (ioctx *IOContext) ApplicationMetadataSet(appName, key, value string) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	cAppName := C.CString(appName)
+	defer C.free(unsafe.Pointer(cAppName))
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cValue := C.CString(value)
+	defer C.free(unsafe.Pointer(cValue))
+
+	ret := C.rados_application_metadata_set(
+		ioctx.ioctx, cAppName, cKey, cValue)
+	return getError(ret)
+}
+
+// ApplicationMetadataRemove removes the metadata key of the application
+// appName from the pool of the IOContext. ErrNotFound is returned if the
+// application is not enabled.
+//
+// Implements:
+//
+//	int rados_application_metadata_remove(rados_ioctx_t io,
+//	                                      const char *app_name,
+//	                                      const char *key);
+func (ioctx *IOContext) ApplicationMetadataRemove(appName, key string) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	cAppName := C.CString(appName)
+	defer C.free(unsafe.Pointer(cAppName))
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	ret := C.rados_application_metadata_remove(ioctx.ioctx, cAppName, cKey)
+	return getError(ret)
+}
+
+// ApplicationMetadataList returns all metadata keys and values of the
+// application appName on the pool of the IOContext. ErrNotFound is returned
+// if the application is not enabled.
+//
+// Implements:
+//
+//	int rados_application_metadata_list(rados_ioctx_t io,
+//	                                    const char *app_name,
+//	                                    char *keys, size_t *key_len,
+//	                                    char *values, size_t *vals_len);
+func (ioctx *IOContext) ApplicationMetadataList(appName string) (map[string]string, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+
+	cAppName := C.CString(appName)
+	defer C.free(unsafe.Pointer(cAppName))
+
+	var (
+		keys    []byte
+		vals    []byte
+		keysLen C.size_t
+		valsLen C.size_t
+		err     error
+	)
+	retry.WithSizes(1024, 1<<20, func(size int) retry.Hint {
+		// as for ApplicationList, reserve a byte for the terminating NUL
+		keysLen = C.size_t(size)
+		valsLen = C.size_t(size)
+		keys = make([]byte, size+1)
+		vals = make([]byte, size+1)
+		ret := C.rados_application_metadata_list(
+			ioctx.ioctx,
+			cAppName,
+			(*C.char)(unsafe.Pointer(&keys[0])),
+			&keysLen,
+			(*C.char)(unsafe.Pointer(&vals[0])),
+			&valsLen)
+		err = getError(ret)
+		needed := keysLen
+		if valsLen > needed {
+			needed = valsLen
+		}
+		return retry.Size(int(needed)).If(err == errRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	keyList := cutil.SplitBuffer(keys[:keysLen])
+	valList := cutil.SplitBuffer(vals[:valsLen])
+	metadata := make(map[string]string, len(keyList))
+	for i := range keyList {
+		if i < len(valList) {
+			metadata[keyList[i]] = valList[i]
+		}
+	}
+	return metadata, nil
+}
@@ -0,0 +1,107 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestApplication() {
+	suite.SetupConnection()
+
+	// the application tags are a property of the pool, so these tests get a
+	// pool of their own
+	pool := uuid.Must(uuid.NewV4()).String()
+	err := suite.conn.MakePool(pool)
+	require.NoError(suite.T(), err)
+	defer func() {
+		assert.NoError(suite.T(), suite.conn.DeletePool(pool))
+	}()
+	ioctx, err := suite.conn.OpenIOContext(pool)
+	require.NoError(suite.T(), err)
+	defer ioctx.Destroy()
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		ioctx := &IOContext{}
+		err := ioctx.ApplicationEnable("foo", false)
+		assert.Equal(t, ErrInvalidIOContext, err)
+		_, err = ioctx.ApplicationList()
+		assert.Equal(t, ErrInvalidIOContext, err)
+		_, err = ioctx.ApplicationMetadataGet("foo", "bar")
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = ioctx.ApplicationMetadataSet("foo", "bar", "baz")
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = ioctx.ApplicationMetadataRemove("foo", "bar")
+		assert.Equal(t, ErrInvalidIOContext, err)
+		_, err = ioctx.ApplicationMetadataList("foo")
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("enable", func(t *testing.T) {
+		apps, err := ioctx.ApplicationList()
+		assert.NoError(t, err)
+		assert.Len(t, apps, 0)
+
+		err = ioctx.ApplicationEnable(ApplicationRBD, false)
+		assert.NoError(t, err)
+		apps, err = ioctx.ApplicationList()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{ApplicationRBD}, apps)
+
+		// enabling the same application again is not an error
+		err = ioctx.ApplicationEnable(ApplicationRBD, false)
+		assert.NoError(t, err)
+
+		err = ioctx.ApplicationEnable("gotest", false)
+		assert.Equal(t, ErrPermissionDenied, err)
+		err = ioctx.ApplicationEnable("gotest", true)
+		assert.NoError(t, err)
+		apps, err = ioctx.ApplicationList()
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{ApplicationRBD, "gotest"}, apps)
+	})
+
+	suite.T().Run("metadata", func(t *testing.T) {
+		md, err := ioctx.ApplicationMetadataList("gotest")
+		assert.NoError(t, err)
+		assert.Len(t, md, 0)
+
+		err = ioctx.ApplicationMetadataSet("gotest", "color", "blue")
+		assert.NoError(t, err)
+		err = ioctx.ApplicationMetadataSet("gotest", "shape", "")
+		assert.NoError(t, err)
+
+		v, err := ioctx.ApplicationMetadataGet("gotest", "color")
+		assert.NoError(t, err)
+		assert.Equal(t, "blue", v)
+
+		md, err = ioctx.ApplicationMetadataList("gotest")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"color": "blue", "shape": ""}, md)
+
+		err = ioctx.ApplicationMetadataRemove("gotest", "color")
+		assert.NoError(t, err)
+		_, err = ioctx.ApplicationMetadataGet("gotest", "color")
+		assert.Equal(t, ErrNotFound, err)
+
+		md, err = ioctx.ApplicationMetadataList("gotest")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"shape": ""}, md)
+	})
+
+	suite.T().Run("notEnabled", func(t *testing.T) {
+		_, err := ioctx.ApplicationMetadataGet("missing", "color")
+		assert.Equal(t, ErrNotFound, err)
+		err = ioctx.ApplicationMetadataSet("missing", "color", "red")
+		assert.Equal(t, ErrNotFound, err)
+		err = ioctx.ApplicationMetadataRemove("missing", "color")
+		assert.Equal(t, ErrNotFound, err)
+		_, err = ioctx.ApplicationMetadataList("missing")
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
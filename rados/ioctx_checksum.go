@@ -0,0 +1,68 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/retry"
+)
+
+// Checksum computes checksums of length bytes of the data of the object with
+// key oid starting at byte offset offset. The checksums are computed by the
+// OSDs, so the data is not transferred to the client. The data is split into
+// chunks of chunkSize bytes and a checksum is returned for each chunk, in
+// order. If chunkSize is zero a single checksum is computed for the whole
+// range. A length of zero selects the data up to the end of the object. The
+// init value is used as the initial value (seed) of each checksum.
+//
+// Implements:
+//
+//	int rados_checksum(rados_ioctx_t io, const char *oid,
+//	                   rados_checksum_type_t type,
+//	                   const char *init_value, size_t init_value_len,
+//	                   size_t len, uint64_t off, size_t chunk_size,
+//	                   char *pchecksum, size_t checksum_len);
+func (ioctx *IOContext) Checksum(oid string, t ChecksumType, init, offset, length, chunkSize uint64) ([]uint64, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+
+	cOid := C.CString(oid)
+	defer C.free(unsafe.Pointer(cOid))
+	initValue := t.initValue(init)
+
+	var (
+		err error
+		buf []byte
+	)
+	// the number of checksums is not known in advance if the length of the
+	// object data is not given
+	start := t.resultSize(checksumCount(length, chunkSize))
+	retry.WithSizes(start, 1<<24, func(size int) retry.Hint {
+		buf = make([]byte, size)
+		ret := C.rados_checksum(
+			ioctx.ioctx,
+			cOid,
+			C.rados_checksum_type_t(t),
+			(*C.char)(unsafe.Pointer(&initValue[0])),
+			C.size_t(len(initValue)),
+			C.size_t(length),
+			C.uint64_t(offset),
+			C.size_t(chunkSize),
+			(*C.char)(unsafe.Pointer(&buf[0])),
+			C.size_t(len(buf)))
+		err = getError(ret)
+		return retry.DoubleSize.If(err == errRange)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.parseResult(buf)
+}
@@ -0,0 +1,76 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestChecksum() {
+	suite.SetupConnection()
+
+	oid := suite.GenObjectName()
+	data := suite.RandomBytes(16384)
+	err := suite.ioctx.WriteFull(oid, data)
+	require.NoError(suite.T(), err)
+
+	// ceph's crc32c does not invert the result like the Go implementation
+	table := crc32.MakeTable(crc32.Castagnoli)
+	crc := func(b []byte) uint64 {
+		return uint64(^crc32.Checksum(b, table))
+	}
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		ioctx := &IOContext{}
+		_, err := ioctx.Checksum(oid, ChecksumCRC32C, 0, 0, 0, 0)
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("whole", func(t *testing.T) {
+		sums, err := suite.ioctx.Checksum(
+			oid, ChecksumCRC32C, 0xffffffff, 0, 0, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []uint64{crc(data)}, sums)
+	})
+
+	suite.T().Run("chunks", func(t *testing.T) {
+		sums, err := suite.ioctx.Checksum(
+			oid, ChecksumCRC32C, 0xffffffff, 4096, 8192, 4096)
+		assert.NoError(t, err)
+		assert.Equal(t,
+			[]uint64{crc(data[4096:8192]), crc(data[8192:12288])}, sums)
+	})
+
+	suite.T().Run("chunksToEnd", func(t *testing.T) {
+		sums, err := suite.ioctx.Checksum(
+			oid, ChecksumCRC32C, 0xffffffff, 0, 0, 1024)
+		assert.NoError(t, err)
+		assert.Len(t, sums, 16)
+		assert.Equal(t, crc(data[15360:]), sums[15])
+	})
+
+	suite.T().Run("xxhash", func(t *testing.T) {
+		sums32, err := suite.ioctx.Checksum(oid, ChecksumXXHash32, 0, 0, 0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, sums32, 1)
+		sums64, err := suite.ioctx.Checksum(oid, ChecksumXXHash64, 0, 0, 0, 0)
+		assert.NoError(t, err)
+		assert.Len(t, sums64, 1)
+
+		// a different seed changes the checksum
+		seeded, err := suite.ioctx.Checksum(oid, ChecksumXXHash64, 1, 0, 0, 0)
+		assert.NoError(t, err)
+		assert.NotEqual(t, sums64, seeded)
+	})
+
+	suite.T().Run("missing", func(t *testing.T) {
+		_, err := suite.ioctx.Checksum(
+			suite.GenObjectName(), ChecksumCRC32C, 0, 0, 0, 0)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
@@ -0,0 +1,142 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"time"
+)
+
+// The context aware variants of the blocking IOContext calls are built on
+// the asynchronous calls. If the context is done before the operation is
+// complete ctx.Err() is returned right away. The operation can not be
+// aborted, so it continues in the cluster, but its outputs are left
+// untouched.
+
+// ReadContext reads up to len(data) bytes from the object with key oid
+// starting at byte offset offset, like Read. It returns ctx.Err() if the
+// context is done before the read is complete, in which case data is not
+// modified. An empty data slice is not read.
+func (ioctx *IOContext) ReadContext(ctx context.Context, oid string, data []byte, offset uint64) (n int, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.ReadContext", oid, n, start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+	comp, err := ioctx.AioRead(oid, data, offset)
+	if err != nil {
+		return 0, err
+	}
+	if err := comp.waitContext(ctx); err != nil {
+		return 0, err
+	}
+	return comp.Result()
+}
+
+// WriteContext writes len(data) bytes to the object with key oid starting at
+// byte offset offset, like Write. It returns ctx.Err() if the context is
+// done before the write is complete, in which case the write may or may not
+// be applied.
+func (ioctx *IOContext) WriteContext(ctx context.Context, oid string, data []byte, offset uint64) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.WriteContext", oid, len(data), start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := ioctx.AioWrite(oid, data, offset)
+	if err != nil {
+		return err
+	}
+	return comp.waitContext(ctx)
+}
+
+// WriteFullContext writes len(data) bytes to the object with key oid,
+// replacing its contents, like WriteFull. It returns ctx.Err() if the
+// context is done before the write is complete, in which case the write may
+// or may not be applied.
+func (ioctx *IOContext) WriteFullContext(ctx context.Context, oid string, data []byte) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.WriteFullContext", oid, len(data), start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := ioctx.AioWriteFull(oid, data)
+	if err != nil {
+		return err
+	}
+	return comp.waitContext(ctx)
+}
+
+// AppendContext appends len(data) bytes to the object with key oid, like
+// Append. It returns ctx.Err() if the context is done before the append is
+// complete, in which case the append may or may not be applied.
+func (ioctx *IOContext) AppendContext(ctx context.Context, oid string, data []byte) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.AppendContext", oid, len(data), start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := ioctx.AioAppend(oid, data)
+	if err != nil {
+		return err
+	}
+	return comp.waitContext(ctx)
+}
+
+// DeleteContext deletes the object with key oid, like Delete. It returns
+// ctx.Err() if the context is done before the object is deleted, in which
+// case the delete may or may not be applied.
+func (ioctx *IOContext) DeleteContext(ctx context.Context, oid string) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.DeleteContext", oid, 0, start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := ioctx.AioRemove(oid)
+	if err != nil {
+		return err
+	}
+	return comp.waitContext(ctx)
+}
+
+// StatContext returns the size and the last modification time of the object
+// with key oid, like Stat. It returns ctx.Err() if the context is done
+// before the stat is complete.
+func (ioctx *IOContext) StatContext(ctx context.Context, oid string) (stat ObjectStat, err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.IOContext.StatContext", oid, 0, start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return ObjectStat{}, err
+	}
+	s := &ObjectStat{}
+	comp, err := ioctx.AioStat(oid, s)
+	if err != nil {
+		return ObjectStat{}, err
+	}
+	if err := comp.waitContext(ctx); err != nil {
+		return ObjectStat{}, err
+	}
+	return *s, nil
+}
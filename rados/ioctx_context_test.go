@@ -0,0 +1,135 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestIOContextContext() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+	ctx := context.Background()
+
+	oid := suite.GenObjectName()
+	err := suite.ioctx.WriteFullContext(ctx, oid, []byte("input"))
+	require.NoError(suite.T(), err)
+	err = suite.ioctx.WriteContext(ctx, oid, []byte("INP"), 0)
+	ta.NoError(err)
+	err = suite.ioctx.AppendContext(ctx, oid, []byte(" data"))
+	ta.NoError(err)
+
+	out := make([]byte, 64)
+	n, err := suite.ioctx.ReadContext(ctx, oid, out, 0)
+	ta.NoError(err)
+	ta.Equal("INPut data", string(out[:n]))
+
+	stat, err := suite.ioctx.StatContext(ctx, oid)
+	ta.NoError(err)
+	ta.EqualValues(10, stat.Size)
+
+	err = suite.ioctx.DeleteContext(ctx, oid)
+	ta.NoError(err)
+	_, err = suite.ioctx.StatContext(ctx, oid)
+	ta.Equal(ErrNotFound, err)
+
+	suite.T().Run("cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		err := suite.ioctx.WriteFullContext(cctx, oid, []byte("input"))
+		assert.Equal(t, context.Canceled, err)
+		_, err = suite.ioctx.ReadContext(cctx, oid, out, 0)
+		assert.Equal(t, context.Canceled, err)
+		_, err = suite.ioctx.StatContext(cctx, oid)
+		assert.Equal(t, context.Canceled, err)
+		// nothing was written
+		_, err = suite.ioctx.Stat(oid)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func (suite *RadosTestSuite) TestOperateContext() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+	ctx := context.Background()
+
+	oid := suite.GenObjectName()
+	wop := CreateWriteOp()
+	defer wop.Release()
+	wop.WriteFull([]byte("input data"))
+	err := wop.OperateContext(ctx, suite.ioctx, oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+
+	rop := CreateReadOp()
+	defer rop.Release()
+	out := make([]byte, 64)
+	rs := rop.Read(0, out)
+	err = rop.OperateContext(ctx, suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal("input data", string(out[:rs.BytesRead]))
+
+	suite.T().Run("cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		wop := CreateWriteOp()
+		defer wop.Release()
+		wop.Remove()
+		err := wop.OperateContext(cctx, suite.ioctx, oid, OperationNoFlag)
+		assert.Equal(t, context.Canceled, err)
+		_, err = suite.ioctx.Stat(oid)
+		assert.NoError(t, err)
+	})
+}
+
+func (suite *RadosTestSuite) TestCommandContext() {
+	suite.SetupConnection()
+	ctx := context.Background()
+
+	buf, _, err := suite.conn.MonCommandContext(ctx,
+		[]byte(`{"prefix": "fsid", "format": "json"}`))
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(buf), "fsid")
+
+	buf, _, err = suite.conn.MgrCommandContext(ctx,
+		[][]byte{[]byte(`{"prefix": "mgr services", "format": "json"}`)})
+	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), buf)
+
+	suite.T().Run("cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, err := suite.conn.MonCommandContext(cctx,
+			[]byte(`{"prefix": "fsid", "format": "json"}`))
+		assert.Equal(t, context.Canceled, err)
+		_, _, err = suite.conn.MgrCommandContext(cctx,
+			[][]byte{[]byte(`{"prefix": "mgr services", "format": "json"}`)})
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	suite.T().Run("deadline", func(t *testing.T) {
+		prev, err := suite.conn.GetConfigOption(monOpTimeoutOption)
+		require.NoError(t, err)
+		dctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		buf, _, err := suite.conn.MonCommandContext(dctx,
+			[]byte(`{"prefix": "fsid", "format": "json"}`))
+		assert.NoError(t, err)
+		assert.Contains(t, string(buf), "fsid")
+		// the timeout of the connection is restored
+		value, err := suite.conn.GetConfigOption(monOpTimeoutOption)
+		assert.NoError(t, err)
+		assert.Equal(t, prev, value)
+	})
+}
+
+func TestTimeoutSeconds(t *testing.T) {
+	assert.Equal(t, "1", timeoutSeconds(time.Millisecond))
+	assert.Equal(t, "1", timeoutSeconds(time.Second))
+	assert.Equal(t, "2", timeoutSeconds(1500*time.Millisecond))
+}
@@ -0,0 +1,33 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+// CopyObject copies the object with key srcOid in the pool and namespace of
+// the I/O context src to the object with key dstOid in the pool and
+// namespace of the I/O context, replacing any existing object. The data,
+// xattrs and omap of the object are copied by the OSDs, without passing
+// through the client.
+//
+// Implements:
+//
+//	void rados_write_op_copy_from(rados_write_op_t write_op,
+//	                              const char *src,
+//	                              rados_ioctx_t src_io,
+//	                              uint64_t src_version,
+//	                              uint32_t src_fadvise_flags);
+func (ioctx *IOContext) CopyObject(src *IOContext, srcOid, dstOid string) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+	if src == nil {
+		return ErrInvalidIOContext
+	}
+	if err := src.validate(); err != nil {
+		return err
+	}
+	op := CreateWriteOp()
+	defer op.Release()
+	op.CopyFrom(src, srcOid, 0, OpFlagNone)
+	return op.operateCompat(ioctx, dstOid)
+}
@@ -0,0 +1,70 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestCopyObject() {
+	suite.SetupConnection()
+
+	src := suite.GenObjectName()
+	data := []byte("copied by the OSDs")
+	op := CreateWriteOp()
+	defer op.Release()
+	op.WriteFull(data)
+	op.SetXattr("color", []byte("blue"))
+	op.SetOmap(map[string][]byte{"a": []byte("1"), "b": []byte("2")})
+	err := op.Operate(suite.ioctx, src, OperationNoFlag)
+	require.NoError(suite.T(), err)
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		err := (&IOContext{}).CopyObject(suite.ioctx, src, "dst")
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = suite.ioctx.CopyObject(&IOContext{}, src, "dst")
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = suite.ioctx.CopyObject(nil, src, "dst")
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("otherNamespace", func(t *testing.T) {
+		dstIoctx, err := suite.conn.OpenIOContext(suite.pool)
+		require.NoError(t, err)
+		defer dstIoctx.Destroy()
+		dstIoctx.SetNamespace(uuid.Must(uuid.NewV4()).String())
+
+		dst := suite.GenObjectName()
+		err = dstIoctx.CopyObject(suite.ioctx, src, dst)
+		assert.NoError(t, err)
+
+		out := make([]byte, 64)
+		n, err := dstIoctx.Read(dst, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, data, out[:n])
+
+		xattrs, err := dstIoctx.ListXattrs(dst)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"color": []byte("blue")}, xattrs)
+
+		omap, err := dstIoctx.GetAllOmapValues(dst, "", "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t,
+			map[string][]byte{"a": []byte("1"), "b": []byte("2")}, omap)
+
+		// the source is left untouched
+		_, err = suite.ioctx.Stat(src)
+		assert.NoError(t, err)
+	})
+
+	suite.T().Run("missingSource", func(t *testing.T) {
+		err := suite.ioctx.CopyObject(
+			suite.ioctx, suite.GenObjectName(), suite.GenObjectName())
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
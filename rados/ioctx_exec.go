@@ -0,0 +1,38 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// Exec executes the method of an object class (cls) on the object with key
+// oid, passing in the input buffer, and returns the output of the method.
+// The method is executed once, as a read operation just like rados_exec
+// does, with the output buffer allocated by librados. That way the size of
+// the output is not limited and the method, which may not be idempotent, is
+// never executed again to retry with a larger buffer.
+//
+// Implements:
+//
+//	void rados_read_op_exec(rados_read_op_t read_op,
+//	                        const char *cls,
+//	                        const char *method,
+//	                        const char *in_buf,
+//	                        size_t in_len,
+//	                        char **out_buf,
+//	                        size_t *out_len,
+//	                        int *prval);
+func (ioctx *IOContext) Exec(oid, cls, method string, in []byte) ([]byte, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+
+	op := CreateReadOp()
+	defer op.Release()
+	step := op.Exec(cls, method, in)
+	if err := op.operateCompat(ioctx, oid); err != nil {
+		return nil, err
+	}
+	if step.Output == nil {
+		return []byte{}, nil
+	}
+	return step.Output, nil
+}
@@ -0,0 +1,47 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests for object class execution use the "hello" object class that
+// is part of the default set of classes loaded by the OSDs.
+
+func (suite *RadosTestSuite) TestExec() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	err := suite.ioctx.Create(oid, CreateExclusive)
+	require.NoError(suite.T(), err)
+
+	out, err := suite.ioctx.Exec(oid, "hello", "say_hello", nil)
+	ta.NoError(err)
+	ta.Equal("Hello, world!", string(out))
+
+	out, err = suite.ioctx.Exec(oid, "hello", "say_hello", []byte("go-ceph"))
+	ta.NoError(err)
+	ta.Equal("Hello, go-ceph!", string(out))
+
+	suite.T().Run("unknownMethod", func(t *testing.T) {
+		_, err := suite.ioctx.Exec(oid, "hello", "no_such_method", nil)
+		assert.Error(t, err)
+	})
+
+	suite.T().Run("unknownClass", func(t *testing.T) {
+		_, err := suite.ioctx.Exec(oid, "no_such_class", "say_hello", nil)
+		assert.Error(t, err)
+	})
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		ioctx := &IOContext{}
+		_, err := ioctx.Exec(oid, "hello", "say_hello", nil)
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+}
@@ -0,0 +1,37 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// SetObserver sets the observer that is informed about the I/O operations
+// performed with the I/O context, which are the object read, write, stat and
+// xattr functions of the IOContext as well as read and write operations.
+// Passing nil removes the observer. The observer must not be changed while
+// the I/O context is in use by other goroutines.
+func (ioctx *IOContext) SetObserver(o observer.Observer) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+	if o == nil {
+		ioctx.observer = nil
+		return nil
+	}
+	pool, err := ioctx.GetPoolName()
+	if err != nil {
+		return err
+	}
+	namespace, err := ioctx.GetNamespace()
+	if err != nil {
+		return err
+	}
+	ioctx.observer = &ioctxObserver{
+		observer:  o,
+		pool:      pool,
+		namespace: namespace,
+	}
+	return nil
+}
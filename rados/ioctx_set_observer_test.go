@@ -0,0 +1,56 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/observertest"
+)
+
+func (suite *RadosTestSuite) TestSetObserver() {
+	suite.SetupConnection()
+	ioctx, err := suite.conn.OpenIOContext(suite.pool)
+	require.NoError(suite.T(), err)
+	defer ioctx.Destroy()
+
+	assert.Equal(suite.T(), ErrInvalidIOContext, (&IOContext{}).SetObserver(nil))
+
+	rec := &observertest.Recorder{}
+	require.NoError(suite.T(), ioctx.SetObserver(rec))
+	ioctx.SetNamespace("observed")
+
+	oid := suite.GenObjectName()
+	data := []byte("observed data")
+	require.NoError(suite.T(), ioctx.WriteFull(oid, data))
+	buf := make([]byte, 64)
+	n, err := ioctx.Read(oid, buf, 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), len(data), n)
+	_, err = ioctx.Stat(oid + "-missing")
+	assert.Equal(suite.T(), ErrNotFound, err)
+	require.NoError(suite.T(), ioctx.SetOmap(oid, map[string][]byte{"a": []byte("b")}))
+
+	ops := rec.Take()
+	require.Len(suite.T(), ops, 4)
+	for _, op := range ops {
+		assert.Equal(suite.T(), suite.pool, op.Pool)
+		assert.Equal(suite.T(), "observed", op.Namespace)
+		assert.Greater(suite.T(), op.Duration.Nanoseconds(), int64(0))
+	}
+	assert.Equal(suite.T(), "rados.IOContext.WriteFull", ops[0].Name)
+	assert.Equal(suite.T(), oid, ops[0].Object)
+	assert.EqualValues(suite.T(), len(data), ops[0].Bytes)
+	assert.NoError(suite.T(), ops[0].Err)
+	assert.Equal(suite.T(), "rados.IOContext.Read", ops[1].Name)
+	assert.EqualValues(suite.T(), len(data), ops[1].Bytes)
+	assert.Equal(suite.T(), "rados.IOContext.Stat", ops[2].Name)
+	assert.Equal(suite.T(), ErrNotFound, ops[2].Err)
+	assert.Equal(suite.T(), "rados.WriteOp.Operate", ops[3].Name)
+
+	require.NoError(suite.T(), ioctx.SetObserver(nil))
+	require.NoError(suite.T(), ioctx.Delete(oid))
+	assert.Len(suite.T(), rec.Take(), 0)
+}
@@ -0,0 +1,409 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #include <errno.h>
+import "C"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ceph/go-ceph/internal/retry"
+)
+
+// ErrUpdateConflict is returned by UpdateObject if the object was modified
+// concurrently in every attempt to update it.
+var ErrUpdateConflict = errors.New("object was modified concurrently")
+
+const (
+	// errOverflow is returned if an asserted object version is greater than
+	// the version of the object.
+	errOverflow = radosError(-C.EOVERFLOW)
+	// errObjectTooLarge is returned if the data of the object is larger than
+	// UpdateObject is able to read.
+	errObjectTooLarge = radosError(-C.EFBIG)
+)
+
+const (
+	updateObjectReadSize = 64 * 1024
+	// updateObjectMaxSize matches the default osd_max_object_size
+	updateObjectMaxSize  = 128 * 1024 * 1024
+	updateObjectOmapPage = 1000
+
+	defaultUpdateObjectAttempts       = 10
+	defaultUpdateObjectInitialBackoff = 10 * time.Millisecond
+	defaultUpdateObjectMaxBackoff     = time.Second
+)
+
+// ObjectState is the state of an object as passed to an ObjectUpdateFunc.
+// Only the parts of the object selected by the UpdateObjectOptions are
+// read and written back.
+type ObjectState struct {
+	// Exists is true if the object exists. Setting it to false removes the
+	// object, setting it to true creates the object if it is missing.
+	Exists bool
+	// Version is the version of the object that was read.
+	Version uint64
+	// Data is the content of the object.
+	Data []byte
+	// Xattrs are the xattrs of the object. Values must not be empty.
+	Xattrs map[string][]byte
+	// Omap is the omap of the object.
+	Omap map[string][]byte
+}
+
+// ObjectUpdateFunc modifies the state of an object in place. It may be
+// called more than once by UpdateObject, with the latest state of the
+// object, and should have no other side effects. If it returns an error the
+// update is aborted and the error is returned by UpdateObject.
+type ObjectUpdateFunc func(state *ObjectState) error
+
+// UpdateObjectOptions selects the parts of the object that are read and
+// written back by UpdateObject and configures how conflicting updates are
+// retried.
+type UpdateObjectOptions struct {
+	Data   bool
+	Xattrs bool
+	Omap   bool
+
+	// MaxAttempts is the number of times the update is attempted. It
+	// defaults to 10.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It is doubled for
+	// every further retry. It defaults to 10 milliseconds.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between retries. It defaults to one
+	// second.
+	MaxBackoff time.Duration
+}
+
+// UpdateObject performs an optimistic read-modify-write cycle on the object
+// with key oid. It reads the parts of the object selected by opts, passes
+// them to updateFn and writes the changes made by updateFn back to the
+// object, guarded by the version of the object that was read. If the object
+// was modified in the meantime, the cycle is repeated, with an exponential
+// backoff, until the update succeeds, the attempts are exhausted, in which
+// case ErrUpdateConflict is returned, or the context is done.
+func (ioctx *IOContext) UpdateObject(
+	ctx context.Context, oid string, opts UpdateObjectOptions,
+	updateFn ObjectUpdateFunc) error {
+
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+	b := retry.Backoff{
+		Attempts: opts.MaxAttempts,
+		Initial:  opts.InitialBackoff,
+		Max:      opts.MaxBackoff,
+	}
+	if b.Attempts <= 0 {
+		b.Attempts = defaultUpdateObjectAttempts
+	}
+	if b.Initial <= 0 {
+		b.Initial = defaultUpdateObjectInitialBackoff
+	}
+	if b.Max <= 0 {
+		b.Max = defaultUpdateObjectMaxBackoff
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var (
+		err      error
+		conflict bool
+	)
+	cerr := retry.WithBackoff(ctx, b, func(int) bool {
+		conflict, err = ioctx.updateObject(oid, opts, updateFn)
+		return conflict
+	})
+	switch {
+	case cerr != nil:
+		return cerr
+	case conflict:
+		return ErrUpdateConflict
+	}
+	return err
+}
+
+// updateObject performs a single read-modify-write cycle. It returns true if
+// the cycle failed because the object was modified concurrently.
+func (ioctx *IOContext) updateObject(
+	oid string, opts UpdateObjectOptions, updateFn ObjectUpdateFunc) (bool, error) {
+
+	orig, err := ioctx.readObjectState(oid, opts)
+	if err != nil {
+		return isVersionConflict(err), err
+	}
+	state := orig.clone()
+	if err := updateFn(state); err != nil {
+		return false, err
+	}
+
+	op := CreateWriteOp()
+	defer op.Release()
+	changed, err := buildObjectUpdate(op, orig, state, opts)
+	if err != nil || !changed {
+		return false, err
+	}
+	err = operationError(op.Operate(ioctx, oid, OperationNoFlag))
+	switch {
+	case isVersionConflict(err):
+		return true, err
+	case orig.Exists && err == ErrNotFound:
+		// removed since it was read
+		return true, err
+	case !orig.Exists && err == ErrObjectExists:
+		// created since it was read
+		return true, err
+	}
+	return false, err
+}
+
+// readObjectState reads the parts of the object selected by opts in a
+// consistent state.
+func (ioctx *IOContext) readObjectState(oid string, opts UpdateObjectOptions) (*ObjectState, error) {
+	var (
+		state  *ObjectState
+		needed int
+		more   bool
+		err    error
+	)
+	retry.WithSizes(updateObjectReadSize, updateObjectMaxSize, func(size int) retry.Hint {
+		state, needed, more, err = ioctx.readObject(oid, opts, size)
+		return retry.Size(needed).If(err == nil && needed > size)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, errObjectTooLarge
+	}
+
+	// read the remaining omap pages, asserting that the object did not
+	// change in the meantime
+	for more {
+		op := CreateReadOp()
+		op.AssertVersion(state.Version)
+		after := lastKey(state.Omap)
+		omap := op.GetOmapValues(after, "", updateObjectOmapPage)
+		err = operationError(op.Operate(ioctx, oid, OperationNoFlag))
+		if err == nil {
+			err = collectOmap(omap, state.Omap)
+			more = omap.More()
+		}
+		op.Release()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// readObject reads the object with a single read operation. If the data of
+// the object does not fit into bufSize bytes, the required size is returned.
+func (ioctx *IOContext) readObject(
+	oid string, opts UpdateObjectOptions, bufSize int) (*ObjectState, int, bool, error) {
+
+	op := CreateReadOp()
+	defer op.Release()
+	stat := op.Stat()
+	var (
+		buf    []byte
+		read   *ReadOpReadStep
+		xattrs *ReadOpGetXattrsStep
+		omap   *GetOmapStep
+	)
+	if opts.Data {
+		buf = make([]byte, bufSize)
+		read = op.Read(0, buf)
+	}
+	if opts.Xattrs {
+		xattrs = op.GetXattrs()
+	}
+	if opts.Omap {
+		omap = op.GetOmapValues("", "", updateObjectOmapPage)
+	}
+
+	// the version is taken from the completion, as the last version of the
+	// I/O context may be changed concurrently by other operations
+	comp, err := op.AioOperate(ioctx, oid, OperationNoFlag)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	err = operationError(comp.Wait())
+
+	state := &ObjectState{}
+	if opts.Xattrs {
+		state.Xattrs = map[string][]byte{}
+	}
+	if opts.Omap {
+		state.Omap = map[string][]byte{}
+	}
+	if err == ErrNotFound {
+		return state, 0, false, nil
+	}
+	if err != nil {
+		return nil, 0, false, err
+	}
+	state.Exists = true
+	state.Version, _ = comp.Version()
+
+	if opts.Data {
+		if stat.Size > uint64(read.BytesRead) {
+			return nil, int(stat.Size), false, nil
+		}
+		state.Data = buf[:read.BytesRead]
+	}
+	if opts.Xattrs {
+		for {
+			x, err := xattrs.Next()
+			if err != nil {
+				return nil, 0, false, err
+			}
+			if x == nil {
+				break
+			}
+			state.Xattrs[x.Name] = x.Value
+		}
+	}
+	more := false
+	if opts.Omap {
+		if err := collectOmap(omap, state.Omap); err != nil {
+			return nil, 0, false, err
+		}
+		more = omap.More()
+	}
+	return state, 0, more, nil
+}
+
+// buildObjectUpdate adds the changes from orig to state to the write
+// operation. It returns false if there are no changes.
+func buildObjectUpdate(op *WriteOp, orig, state *ObjectState, opts UpdateObjectOptions) (bool, error) {
+	switch {
+	case !orig.Exists && !state.Exists:
+		return false, nil
+	case orig.Exists && !state.Exists:
+		op.AssertVersion(orig.Version)
+		op.Remove()
+		return true, nil
+	case !orig.Exists:
+		op.Create(CreateExclusive)
+	default:
+		op.AssertVersion(orig.Version)
+	}
+
+	changed := !orig.Exists
+	if opts.Data && !bytes.Equal(orig.Data, state.Data) {
+		if len(state.Data) == 0 {
+			op.Truncate(0)
+		} else {
+			op.WriteFull(state.Data)
+		}
+		changed = true
+	}
+	if opts.Xattrs {
+		for name, value := range state.Xattrs {
+			if v, ok := orig.Xattrs[name]; ok && bytes.Equal(v, value) {
+				continue
+			}
+			if len(value) == 0 {
+				return false, ErrEmptyArgument
+			}
+			op.SetXattr(name, value)
+			changed = true
+		}
+		for name := range orig.Xattrs {
+			if _, ok := state.Xattrs[name]; !ok {
+				op.RmXattr(name)
+				changed = true
+			}
+		}
+	}
+	if opts.Omap {
+		set := map[string][]byte{}
+		for key, value := range state.Omap {
+			if v, ok := orig.Omap[key]; !ok || !bytes.Equal(v, value) {
+				set[key] = value
+			}
+		}
+		rm := []string{}
+		for key := range orig.Omap {
+			if _, ok := state.Omap[key]; !ok {
+				rm = append(rm, key)
+			}
+		}
+		if len(set) > 0 {
+			op.SetOmap(set)
+			changed = true
+		}
+		if len(rm) > 0 {
+			op.RmOmapKeys(rm)
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+func (s *ObjectState) clone() *ObjectState {
+	c := &ObjectState{
+		Exists:  s.Exists,
+		Version: s.Version,
+		Data:    append([]byte(nil), s.Data...),
+	}
+	if s.Xattrs != nil {
+		c.Xattrs = make(map[string][]byte, len(s.Xattrs))
+		for k, v := range s.Xattrs {
+			c.Xattrs[k] = append([]byte(nil), v...)
+		}
+	}
+	if s.Omap != nil {
+		c.Omap = make(map[string][]byte, len(s.Omap))
+		for k, v := range s.Omap {
+			c.Omap[k] = append([]byte(nil), v...)
+		}
+	}
+	return c
+}
+
+func collectOmap(step *GetOmapStep, m map[string][]byte) error {
+	for {
+		kv, err := step.Next()
+		if err != nil {
+			return err
+		}
+		if kv == nil {
+			return nil
+		}
+		m[kv.Key] = kv.Value
+	}
+}
+
+func lastKey(m map[string][]byte) string {
+	last := ""
+	for k := range m {
+		if k > last {
+			last = k
+		}
+	}
+	return last
+}
+
+// operationError returns the error of the operation itself, rather than the
+// OperationError wrapping it.
+func operationError(err error) error {
+	if oe, ok := err.(OperationError); ok && oe.OpError != nil {
+		return oe.OpError
+	}
+	return err
+}
+
+// isVersionConflict returns true if the error is caused by an asserted
+// version that does not match the version of the object.
+func isVersionConflict(err error) bool {
+	return err == errRange || err == errOverflow
+}
@@ -0,0 +1,182 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestUpdateObject() {
+	suite.SetupConnection()
+	all := UpdateObjectOptions{Data: true, Xattrs: true, Omap: true}
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		err := (&IOContext{}).UpdateObject(
+			context.Background(), "oid", all,
+			func(*ObjectState) error { return nil })
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("createModifyRemove", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		err := suite.ioctx.UpdateObject(context.Background(), oid, all,
+			func(s *ObjectState) error {
+				assert.False(t, s.Exists)
+				s.Exists = true
+				s.Data = []byte("hello")
+				s.Xattrs["color"] = []byte("blue")
+				s.Omap["a"] = []byte("1")
+				s.Omap["b"] = []byte("2")
+				return nil
+			})
+		require.NoError(t, err)
+
+		err = suite.ioctx.UpdateObject(context.Background(), oid, all,
+			func(s *ObjectState) error {
+				assert.True(t, s.Exists)
+				assert.NotZero(t, s.Version)
+				assert.Equal(t, []byte("hello"), s.Data)
+				assert.Equal(t, map[string][]byte{"color": []byte("blue")}, s.Xattrs)
+				assert.Equal(t,
+					map[string][]byte{"a": []byte("1"), "b": []byte("2")}, s.Omap)
+				s.Data = append(s.Data, " world"...)
+				delete(s.Xattrs, "color")
+				s.Xattrs["shape"] = []byte("round")
+				delete(s.Omap, "a")
+				s.Omap["b"] = []byte("3")
+				return nil
+			})
+		require.NoError(t, err)
+
+		out := make([]byte, 32)
+		n, err := suite.ioctx.Read(oid, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello world", string(out[:n]))
+		xattrs, err := suite.ioctx.ListXattrs(oid)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"shape": []byte("round")}, xattrs)
+		omap, err := suite.ioctx.GetAllOmapValues(oid, "", "", 10)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"b": []byte("3")}, omap)
+
+		err = suite.ioctx.UpdateObject(context.Background(), oid,
+			UpdateObjectOptions{},
+			func(s *ObjectState) error {
+				s.Exists = false
+				return nil
+			})
+		assert.NoError(t, err)
+		_, err = suite.ioctx.Stat(oid)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	suite.T().Run("abort", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		errAbort := errors.New("abort")
+		err := suite.ioctx.UpdateObject(context.Background(), oid, all,
+			func(s *ObjectState) error {
+				s.Exists = true
+				return errAbort
+			})
+		assert.Equal(t, errAbort, err)
+		_, err = suite.ioctx.Stat(oid)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	suite.T().Run("largeObject", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		data := suite.RandomBytes(1 << 20)
+		err := suite.ioctx.WriteFull(oid, data)
+		require.NoError(t, err)
+		omap := map[string][]byte{}
+		for i := 0; i < 2500; i++ {
+			omap[fmt.Sprintf("key%05d", i)] = []byte("v")
+		}
+		err = suite.ioctx.SetOmap(oid, omap)
+		require.NoError(t, err)
+
+		err = suite.ioctx.UpdateObject(context.Background(), oid, all,
+			func(s *ObjectState) error {
+				assert.Equal(t, data, s.Data)
+				assert.Equal(t, omap, s.Omap)
+				s.Data = nil
+				return nil
+			})
+		assert.NoError(t, err)
+		stat, err := suite.ioctx.Stat(oid)
+		assert.NoError(t, err)
+		assert.EqualValues(t, 0, stat.Size)
+	})
+
+	suite.T().Run("concurrent", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		opts := UpdateObjectOptions{Data: true, MaxAttempts: 100}
+		increment := func(s *ObjectState) error {
+			n := 0
+			if s.Exists {
+				var err error
+				if n, err = strconv.Atoi(string(s.Data)); err != nil {
+					return err
+				}
+			}
+			s.Exists = true
+			s.Data = []byte(strconv.Itoa(n + 1))
+			return nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 10; j++ {
+					err := suite.ioctx.UpdateObject(
+						context.Background(), oid, opts, increment)
+					assert.NoError(t, err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		out := make([]byte, 8)
+		n, err := suite.ioctx.Read(oid, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "50", string(out[:n]))
+	})
+
+	suite.T().Run("conflict", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		err := suite.ioctx.WriteFull(oid, []byte("v1"))
+		require.NoError(t, err)
+		attempts := 0
+		err = suite.ioctx.UpdateObject(context.Background(), oid,
+			UpdateObjectOptions{Data: true, MaxAttempts: 3},
+			func(s *ObjectState) error {
+				attempts++
+				// modify the object behind the back of UpdateObject
+				err := suite.ioctx.Append(oid, []byte("x"))
+				require.NoError(t, err)
+				s.Data = []byte("v2")
+				return nil
+			})
+		assert.Equal(t, ErrUpdateConflict, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	suite.T().Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := suite.ioctx.UpdateObject(ctx, suite.GenObjectName(), all,
+			func(*ObjectState) error { return nil })
+		assert.Equal(t, context.Canceled, err)
+	})
+}
@@ -0,0 +1,336 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #include <errno.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLeaseLost is returned by Lease.Err if the lock was taken over by
+	// another client, e.g. because it was broken.
+	ErrLeaseLost = errors.New("lease lock taken over by another client")
+	// ErrLeaseExpired is returned by Lease.Err if the lock could not be
+	// renewed before the lease duration expired.
+	ErrLeaseExpired = errors.New("lease expired before it could be renewed")
+	// ErrLeaseAcquired is returned by Lease.Acquire if the Lease has already
+	// been acquired. A Lease can only be acquired once.
+	ErrLeaseAcquired = errors.New("lease has already been acquired")
+	// ErrLeaseCookieInUse is returned by Lease.Acquire if the client already
+	// holds the lock with the cookie of the Lease, e.g. because the cookie is
+	// used by another Lease of the same connection.
+	ErrLeaseCookieInUse = errors.New("lease lock is already held with the same cookie")
+	// ErrInvalidRenewInterval is returned by NewLease if the RenewInterval
+	// option is not less than the Duration option.
+	ErrInvalidRenewInterval = errors.New("lease renew interval must be less than its duration")
+)
+
+const (
+	defaultLeaseDuration      = 30 * time.Second
+	defaultLeaseRetryInterval = time.Second
+)
+
+// LockHolder identifies a client holding a lock on an object.
+type LockHolder struct {
+	Client string
+	Cookie string
+	Addr   string
+}
+
+// LeaseOptions configures a Lease. All fields are optional.
+type LeaseOptions struct {
+	// Duration is the time the lock is held for without being renewed. It
+	// defaults to 30 seconds.
+	Duration time.Duration
+	// RenewInterval is the time between renewals of the lock. It defaults to
+	// a third of Duration and must be less than Duration.
+	RenewInterval time.Duration
+	// RetryInterval is the time Acquire waits before trying again to take a
+	// lock held by another client. It defaults to one second.
+	RetryInterval time.Duration
+	// Cookie identifies the lock holder together with the client. A random
+	// cookie is generated if it is empty.
+	Cookie string
+	// Description is stored along with the lock.
+	Description string
+	// BreakStale, if set, is called by Acquire for every other holder of the
+	// lock. If it returns true the lock of the holder is broken. It can be
+	// used to take over locks held without a duration by clients that are
+	// known to be gone.
+	BreakStale func(holder LockHolder) bool
+}
+
+// Lease is an exclusive lock on an object that is renewed in the background
+// until it is released. If the lock can not be renewed the channel returned
+// by Lost is closed, and the holder must stop acting as the owner of the
+// lock.
+type Lease struct {
+	ioctx *IOContext
+	oid   string
+	name  string
+	opts  LeaseOptions
+
+	lost chan struct{}
+	stop chan struct{}
+	done chan struct{}
+
+	// acquireMutex serializes calls to Acquire, mutex protects the state
+	acquireMutex sync.Mutex
+	mutex        sync.Mutex
+	acquired     bool
+	released     bool
+	err          error
+}
+
+// NewLease returns a Lease for the lock with the given name on the object
+// with key oid. The lock is not taken until Acquire is called.
+func (ioctx *IOContext) NewLease(oid, name string, opts LeaseOptions) (*Lease, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	if opts.Duration <= 0 {
+		opts.Duration = defaultLeaseDuration
+	}
+	if opts.RenewInterval <= 0 {
+		opts.RenewInterval = opts.Duration / 3
+	}
+	if opts.RenewInterval >= opts.Duration {
+		return nil, ErrInvalidRenewInterval
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = defaultLeaseRetryInterval
+	}
+	if opts.Cookie == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		opts.Cookie = hex.EncodeToString(b)
+	}
+	return &Lease{
+		ioctx: ioctx,
+		oid:   oid,
+		name:  name,
+		opts:  opts,
+		lost:  make(chan struct{}),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}, nil
+}
+
+// Cookie returns the cookie the lock is taken with.
+func (l *Lease) Cookie() string {
+	return l.opts.Cookie
+}
+
+// lock takes or renews the lock and returns the return code of
+// LockExclusive.
+func (l *Lease) lock(renew bool) (int, error) {
+	var flags *byte
+	if renew {
+		f := byte(C.LIBRADOS_LOCK_FLAG_RENEW)
+		flags = &f
+	}
+	return l.ioctx.LockExclusive(
+		l.oid, l.name, l.opts.Cookie, l.opts.Description,
+		l.opts.Duration, flags)
+}
+
+// breakStale breaks the locks of the holders selected by the BreakStale
+// option and returns true if any lock was broken.
+func (l *Lease) breakStale() (bool, error) {
+	if l.opts.BreakStale == nil {
+		return false, nil
+	}
+	info, err := l.ioctx.ListLockers(l.oid, l.name)
+	if err != nil {
+		return false, err
+	}
+	broken := false
+	for i := range info.Clients {
+		h := LockHolder{Client: info.Clients[i]}
+		if i < len(info.Cookies) {
+			h.Cookie = info.Cookies[i]
+		}
+		if i < len(info.Addrs) {
+			h.Addr = info.Addrs[i]
+		}
+		if !l.opts.BreakStale(h) {
+			continue
+		}
+		ret, err := l.ioctx.BreakLock(l.oid, l.name, h.Client, h.Cookie)
+		if err != nil {
+			return false, err
+		}
+		// the lock may have been released or broken concurrently
+		broken = broken || ret == 0 || ret == -C.ENOENT
+	}
+	return broken, nil
+}
+
+// Acquire takes the lock, waiting while it is held by another client, and
+// starts renewing it in the background. It returns the error of the context
+// if the context is done before the lock could be taken, and
+// ErrLeaseCookieInUse if the client holds the lock with the same cookie
+// already.
+func (l *Lease) Acquire(ctx context.Context) error {
+	l.acquireMutex.Lock()
+	defer l.acquireMutex.Unlock()
+	l.mutex.Lock()
+	acquired := l.acquired
+	l.mutex.Unlock()
+	if acquired {
+		return ErrLeaseAcquired
+	}
+
+	for {
+		start := time.Now()
+		ret, err := l.lock(false)
+		if err != nil {
+			return err
+		}
+		switch ret {
+		case 0:
+			l.mutex.Lock()
+			l.acquired = true
+			l.mutex.Unlock()
+			go l.renew(start.Add(l.opts.Duration))
+			return nil
+		case -C.EEXIST:
+			// taking over would make two holders of the exclusive lock
+			return ErrLeaseCookieInUse
+		case -C.EBUSY:
+			// held by another client
+		default:
+			return getError(C.int(ret))
+		}
+		broken, err := l.breakStale()
+		if err != nil {
+			return err
+		}
+		if broken {
+			continue
+		}
+
+		t := time.NewTimer(l.opts.RetryInterval)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+type leaseRenewal struct {
+	start time.Time
+	ret   int
+	err   error
+}
+
+// renew renews the lock every RenewInterval until the lease is released or
+// lost.
+func (l *Lease) renew(expires time.Time) {
+	defer close(l.done)
+	ticker := time.NewTicker(l.opts.RenewInterval)
+	defer ticker.Stop()
+	expiry := time.NewTimer(time.Until(expires))
+	defer expiry.Stop()
+
+	// renewals run in their own goroutine so that expiry is detected even if
+	// a renewal blocks
+	results := make(chan leaseRenewal, 1)
+	pending := false
+	defer func() {
+		// a pending renewal could take the lock again after it is released
+		if pending {
+			<-results
+		}
+	}()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-expiry.C:
+			l.lose(ErrLeaseExpired)
+			return
+		case <-ticker.C:
+			if pending {
+				continue
+			}
+			pending = true
+			go func() {
+				start := time.Now()
+				ret, err := l.lock(true)
+				results <- leaseRenewal{start: start, ret: ret, err: err}
+			}()
+		case r := <-results:
+			pending = false
+			if r.err != nil {
+				// try again until the lease expires
+				continue
+			}
+			if r.ret != 0 {
+				l.lose(ErrLeaseLost)
+				return
+			}
+			if !expiry.Stop() {
+				<-expiry.C
+			}
+			expiry.Reset(time.Until(r.start.Add(l.opts.Duration)))
+		}
+	}
+}
+
+func (l *Lease) lose(err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.err = err
+	close(l.lost)
+}
+
+// Lost returns a channel that is closed if the lock is lost, either because
+// it could not be renewed in time or because another client took it over.
+// The reason is returned by Err.
+func (l *Lease) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Err returns the reason the lock was lost, or nil if it was not lost.
+func (l *Lease) Err() error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.err
+}
+
+// Release stops renewing the lock and releases it. Releasing a Lease that
+// was lost or never acquired is not an error.
+func (l *Lease) Release() error {
+	l.mutex.Lock()
+	if !l.acquired || l.released {
+		l.mutex.Unlock()
+		return nil
+	}
+	l.released = true
+	l.mutex.Unlock()
+
+	close(l.stop)
+	<-l.done
+	if l.Err() != nil {
+		return nil
+	}
+	// -ENOENT if the lock has expired or was broken in the meantime
+	_, err := l.ioctx.Unlock(l.oid, l.name, l.opts.Cookie)
+	return err
+}
@@ -0,0 +1,138 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestLease() {
+	suite.SetupConnection()
+
+	opts := LeaseOptions{
+		Duration:      2 * time.Second,
+		RenewInterval: 500 * time.Millisecond,
+		RetryInterval: 100 * time.Millisecond,
+		Description:   "lease test",
+	}
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		_, err := (&IOContext{}).NewLease("oid", "lock", opts)
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("invalidRenewInterval", func(t *testing.T) {
+		_, err := suite.ioctx.NewLease("oid", "lock", LeaseOptions{
+			Duration:      time.Second,
+			RenewInterval: time.Second,
+		})
+		assert.Equal(t, ErrInvalidRenewInterval, err)
+	})
+
+	suite.T().Run("cookieInUse", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		ret, err := suite.ioctx.LockExclusive(oid, "lock", "cookie", "", 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, ret)
+		defer func() {
+			_, err := suite.ioctx.Unlock(oid, "lock", "cookie")
+			assert.NoError(t, err)
+		}()
+
+		o := opts
+		o.Cookie = "cookie"
+		l, err := suite.ioctx.NewLease(oid, "lock", o)
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		assert.Equal(t, ErrLeaseCookieInUse, l.Acquire(ctx))
+		assert.NoError(t, l.Release())
+	})
+
+	suite.T().Run("acquireRelease", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		l1, err := suite.ioctx.NewLease(oid, "lock", opts)
+		require.NoError(t, err)
+		l2, err := suite.ioctx.NewLease(oid, "lock", opts)
+		require.NoError(t, err)
+		assert.NotEqual(t, l1.Cookie(), l2.Cookie())
+
+		err = l1.Acquire(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, ErrLeaseAcquired, l1.Acquire(context.Background()))
+
+		// the lock stays held for longer than its duration
+		time.Sleep(2 * opts.Duration)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err = l2.Acquire(ctx)
+		assert.Equal(t, context.DeadlineExceeded, err)
+		assert.NoError(t, l1.Err())
+
+		assert.NoError(t, l1.Release())
+		assert.NoError(t, l1.Release())
+		err = l2.Acquire(context.Background())
+		assert.NoError(t, err)
+		assert.NoError(t, l2.Release())
+
+		info, err := suite.ioctx.ListLockers(oid, "lock")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, info.NumLockers)
+	})
+
+	suite.T().Run("lost", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		l1, err := suite.ioctx.NewLease(oid, "lock", opts)
+		require.NoError(t, err)
+		err = l1.Acquire(context.Background())
+		require.NoError(t, err)
+
+		info, err := suite.ioctx.ListLockers(oid, "lock")
+		require.NoError(t, err)
+		require.Len(t, info.Clients, 1)
+		ret, err := suite.ioctx.BreakLock(
+			oid, "lock", info.Clients[0], l1.Cookie())
+		require.NoError(t, err)
+		require.Equal(t, 0, ret)
+
+		l2, err := suite.ioctx.NewLease(oid, "lock", opts)
+		require.NoError(t, err)
+		err = l2.Acquire(context.Background())
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, l2.Release()) }()
+
+		select {
+		case <-l1.Lost():
+		case <-time.After(opts.Duration):
+			t.Fatal("lease was not lost")
+		}
+		assert.Equal(t, ErrLeaseLost, l1.Err())
+		assert.NoError(t, l1.Release())
+	})
+
+	suite.T().Run("breakStale", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		// a lock without a duration never expires
+		ret, err := suite.ioctx.LockExclusive(oid, "lock", "stale", "", 0, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, ret)
+
+		o := opts
+		o.BreakStale = func(h LockHolder) bool {
+			return h.Cookie == "stale"
+		}
+		l, err := suite.ioctx.NewLease(oid, "lock", o)
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		err = l.Acquire(ctx)
+		assert.NoError(t, err)
+		assert.NoError(t, l.Release())
+	})
+}
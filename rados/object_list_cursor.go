@@ -0,0 +1,323 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <errno.h>
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+)
+
+// objectListPartsPerShard is the number of parts each shard created by
+// SplitObjectList is divided into. A part is the smallest unit of progress
+// tracked by an ObjectListCursor.
+const objectListPartsPerShard = 32
+
+// errInvalidCursor is returned if an ObjectListCursor does not describe a
+// valid range of parts.
+var errInvalidCursor = errors.New("invalid object list cursor")
+
+// errCursorAlloc is returned if librados fails to allocate a cursor.
+const errCursorAlloc = radosError(-C.ENOMEM)
+
+// ObjectListItem is an object returned by an ObjectLister.
+type ObjectListItem struct {
+	Oid       string
+	Namespace string
+	Locator   string
+}
+
+// ObjectListCursor is a serializable position in the listing of the objects
+// of a pool. The hash space of the pool is divided into Parts equally sized
+// parts, and the cursor covers the parts Part up to, but not including, End.
+// Since the parts are defined by the object hashes and not by the objects
+// themselves, a cursor stays valid across restarts of the application and
+// changes of the pool, e.g. a change in the number of placement groups.
+//
+// A cursor can be serialized using MarshalText, which also makes it usable
+// with encoding/json, and deserialized using UnmarshalText.
+type ObjectListCursor struct {
+	Part  uint32
+	End   uint32
+	Parts uint32
+}
+
+// SplitObjectList returns shards cursors that together cover all objects of
+// a pool and can be listed concurrently, by one ObjectLister each.
+func SplitObjectList(shards int) ([]ObjectListCursor, error) {
+	if shards < 1 {
+		return nil, errInvalidCursor
+	}
+	parts := uint32(shards * objectListPartsPerShard)
+	cursors := make([]ObjectListCursor, shards)
+	for i := range cursors {
+		cursors[i] = ObjectListCursor{
+			Part:  uint32(i * objectListPartsPerShard),
+			End:   uint32((i + 1) * objectListPartsPerShard),
+			Parts: parts,
+		}
+	}
+	return cursors, nil
+}
+
+// Done returns true if the cursor has no parts left to list.
+func (c ObjectListCursor) Done() bool {
+	return c.Part >= c.End
+}
+
+func (c ObjectListCursor) validate() error {
+	if c.Parts == 0 || c.Part > c.End || c.End > c.Parts {
+		return errInvalidCursor
+	}
+	return nil
+}
+
+// String returns the text form of the cursor, e.g. "3-32/128".
+func (c ObjectListCursor) String() string {
+	return fmt.Sprintf("%d-%d/%d", c.Part, c.End, c.Parts)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (c ObjectListCursor) MarshalText() ([]byte, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *ObjectListCursor) UnmarshalText(text []byte) error {
+	var p ObjectListCursor
+	_, err := fmt.Sscanf(string(text), "%d-%d/%d", &p.Part, &p.End, &p.Parts)
+	// reject trailing garbage and non canonical forms
+	if err != nil || p.String() != string(text) {
+		return errInvalidCursor
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+	*c = p
+	return nil
+}
+
+// ObjectLister lists the objects of a pool that are covered by an
+// ObjectListCursor. The objects are listed using the namespace setting of
+// the I/O context, call SetNamespace with AllNamespaces before creating the
+// ObjectLister to list objects from all namespaces.
+type ObjectLister struct {
+	ioctx  *IOContext
+	cursor ObjectListCursor
+
+	// begin and end cover the whole pool, start and finish the current part
+	begin, end    C.rados_object_list_cursor
+	start, finish C.rados_object_list_cursor
+	inPart        bool
+}
+
+// NewObjectLister creates an ObjectLister listing the objects covered by
+// cursor. The ObjectLister must be closed with Close when it is no longer
+// needed.
+//
+// Implements:
+//
+//	rados_object_list_cursor rados_object_list_begin(rados_ioctx_t io);
+//	rados_object_list_cursor rados_object_list_end(rados_ioctx_t io);
+func (ioctx *IOContext) NewObjectLister(cursor ObjectListCursor) (*ObjectLister, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	if err := cursor.validate(); err != nil {
+		return nil, err
+	}
+	l := &ObjectLister{ioctx: ioctx, cursor: cursor}
+	// the slice cursors are overwritten by rados_object_list_slice, but they
+	// have to be allocated by librados
+	for _, c := range []*C.rados_object_list_cursor{
+		&l.begin, &l.start, &l.finish,
+	} {
+		*c = C.rados_object_list_begin(ioctx.ioctx)
+		if *c == nil {
+			l.Close()
+			return nil, errCursorAlloc
+		}
+	}
+	l.end = C.rados_object_list_end(ioctx.ioctx)
+	if l.end == nil {
+		l.Close()
+		return nil, errCursorAlloc
+	}
+	return l, nil
+}
+
+// Close frees the resources associated with the ObjectLister.
+//
+// Implements:
+//
+//	void rados_object_list_cursor_free(rados_ioctx_t io,
+//	                                   rados_object_list_cursor c);
+func (l *ObjectLister) Close() {
+	for _, c := range []*C.rados_object_list_cursor{
+		&l.begin, &l.end, &l.start, &l.finish,
+	} {
+		if *c != nil {
+			C.rados_object_list_cursor_free(l.ioctx.ioctx, *c)
+			*c = nil
+		}
+	}
+}
+
+// Cursor returns a cursor covering the objects that have not been returned
+// by Next yet. As progress is tracked in parts, resuming from the returned
+// cursor may return objects of the current part again.
+func (l *ObjectLister) Cursor() ObjectListCursor {
+	return l.cursor
+}
+
+// Next returns up to maxItems objects. It returns an empty slice and no
+// error once all objects covered by the cursor have been listed.
+//
+// Implements:
+//
+//	void rados_object_list_slice(rados_ioctx_t io,
+//	                             const rados_object_list_cursor start,
+//	                             const rados_object_list_cursor finish,
+//	                             const size_t n, const size_t m,
+//	                             rados_object_list_cursor *split_start,
+//	                             rados_object_list_cursor *split_finish);
+//	int rados_object_list(rados_ioctx_t io,
+//	                      const rados_object_list_cursor start,
+//	                      const rados_object_list_cursor finish,
+//	                      const size_t result_size,
+//	                      const char *filter_buf,
+//	                      const size_t filter_buf_len,
+//	                      rados_object_list_item *results,
+//	                      rados_object_list_cursor *next);
+func (l *ObjectLister) Next(maxItems int) ([]ObjectListItem, error) {
+	if l.begin == nil {
+		return nil, ErrInvalidIOContext
+	}
+	if maxItems < 1 {
+		maxItems = defaultListObjectsResultSize
+	}
+	results := make([]C.rados_object_list_item, maxItems)
+	res := (*C.rados_object_list_item)(unsafe.Pointer(&results[0]))
+
+	for !l.cursor.Done() {
+		if !l.inPart {
+			C.rados_object_list_slice(
+				l.ioctx.ioctx,
+				l.begin,
+				l.end,
+				C.size_t(l.cursor.Part),
+				C.size_t(l.cursor.Parts),
+				&l.start,
+				&l.finish)
+			l.inPart = true
+		}
+		if C.rados_object_list_cursor_cmp(l.ioctx.ioctx, l.start, l.finish) >= 0 {
+			l.cursor.Part++
+			l.inPart = false
+			continue
+		}
+
+		ret := C.rados_object_list(
+			l.ioctx.ioctx,
+			l.start,
+			l.finish,
+			C.size_t(maxItems),
+			nil,
+			0,
+			res,
+			&l.start)
+		if ret < 0 {
+			return nil, getError(ret)
+		}
+		if ret == 0 {
+			continue
+		}
+		items := make([]ObjectListItem, int(ret))
+		for i := range items {
+			item := results[i]
+			items[i] = ObjectListItem{
+				Oid:       C.GoStringN(item.oid, C.int(item.oid_length)),
+				Namespace: C.GoStringN(item.nspace, C.int(item.nspace_length)),
+				Locator:   C.GoStringN(item.locator, C.int(item.locator_length)),
+			}
+		}
+		C.rados_object_list_free(C.size_t(ret), res)
+		return items, nil
+	}
+	return []ObjectListItem{}, nil
+}
+
+// ObjectListItemFunc is the type of the function called for each object
+// visited by ListObjectsConcurrently.
+type ObjectListItemFunc func(item ObjectListItem) error
+
+// ListObjectsConcurrently lists all objects of the pool associated with the
+// I/O context using shards concurrent ObjectListers, calling listFn for each
+// object. listFn is called from multiple goroutines and must be safe for
+// concurrent use. Listing stops at the first error, which is returned.
+func (ioctx *IOContext) ListObjectsConcurrently(shards int, listFn ObjectListItemFunc) error {
+	cursors, err := SplitObjectList(shards)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		firstErr error
+	)
+	failed := func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return firstErr != nil
+	}
+	fail := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, cursor := range cursors {
+		wg.Add(1)
+		go func(cursor ObjectListCursor) {
+			defer wg.Done()
+			l, err := ioctx.NewObjectLister(cursor)
+			if err != nil {
+				fail(err)
+				return
+			}
+			defer l.Close()
+			for !failed() {
+				items, err := l.Next(defaultListObjectsResultSize)
+				if err != nil {
+					fail(err)
+					return
+				}
+				if len(items) == 0 {
+					return
+				}
+				for _, item := range items {
+					if err := listFn(item); err != nil {
+						fail(err)
+						return
+					}
+				}
+			}
+		}(cursor)
+	}
+	wg.Wait()
+	return firstErr
+}
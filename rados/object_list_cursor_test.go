@@ -0,0 +1,115 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObjectListCursorText(t *testing.T) {
+	cursors, err := SplitObjectList(4)
+	require.NoError(t, err)
+	require.Len(t, cursors, 4)
+	assert.Equal(t, ObjectListCursor{Part: 0, End: 32, Parts: 128}, cursors[0])
+	assert.Equal(t, ObjectListCursor{Part: 96, End: 128, Parts: 128}, cursors[3])
+
+	_, err = SplitObjectList(0)
+	assert.Equal(t, errInvalidCursor, err)
+
+	b, err := json.Marshal(cursors[1])
+	assert.NoError(t, err)
+	assert.Equal(t, `"32-64/128"`, string(b))
+	var c ObjectListCursor
+	err = json.Unmarshal(b, &c)
+	assert.NoError(t, err)
+	assert.Equal(t, cursors[1], c)
+
+	for _, s := range []string{"", "1-2", "1-2/3x", "3-2/4", "1-5/4", "0-0/0", "-1-2/3"} {
+		assert.Equal(t, errInvalidCursor, c.UnmarshalText([]byte(s)), s)
+	}
+	assert.Equal(t, cursors[1], c)
+}
+
+func (suite *RadosTestSuite) TestObjectLister() {
+	suite.SetupConnection()
+
+	ioctx, err := suite.conn.OpenIOContext(suite.pool)
+	require.NoError(suite.T(), err)
+	defer ioctx.Destroy()
+	ns := uuid.Must(uuid.NewV4()).String()
+	ioctx.SetNamespace(ns)
+
+	expected := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		oid := suite.GenObjectName()
+		err := ioctx.Create(oid, CreateExclusive)
+		require.NoError(suite.T(), err)
+		expected[oid] = true
+	}
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		_, err := (&IOContext{}).NewObjectLister(ObjectListCursor{0, 1, 1})
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("invalidCursor", func(t *testing.T) {
+		_, err := ioctx.NewObjectLister(ObjectListCursor{2, 1, 4})
+		assert.Equal(t, errInvalidCursor, err)
+	})
+
+	suite.T().Run("concurrently", func(t *testing.T) {
+		var mutex sync.Mutex
+		found := map[string]bool{}
+		err := ioctx.ListObjectsConcurrently(4, func(item ObjectListItem) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			assert.False(t, found[item.Oid])
+			found[item.Oid] = true
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, found)
+	})
+
+	suite.T().Run("resume", func(t *testing.T) {
+		cursors, err := SplitObjectList(1)
+		require.NoError(t, err)
+		l, err := ioctx.NewObjectLister(cursors[0])
+		require.NoError(t, err)
+		found := map[string]bool{}
+		items, err := l.Next(5)
+		assert.NoError(t, err)
+		for _, item := range items {
+			found[item.Oid] = true
+		}
+		text, err := l.Cursor().MarshalText()
+		assert.NoError(t, err)
+		l.Close()
+
+		var cursor ObjectListCursor
+		require.NoError(t, cursor.UnmarshalText(text))
+		l, err = ioctx.NewObjectLister(cursor)
+		require.NoError(t, err)
+		defer l.Close()
+		for {
+			items, err := l.Next(7)
+			require.NoError(t, err)
+			if len(items) == 0 {
+				break
+			}
+			for _, item := range items {
+				assert.Equal(t, ns, item.Namespace)
+				found[item.Oid] = true
+			}
+		}
+		assert.True(t, l.Cursor().Done())
+		assert.Equal(t, expected, found)
+	})
+}
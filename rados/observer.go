@@ -0,0 +1,33 @@
+package rados
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// ioctxObserver holds the observer of an IOContext along with the details of
+// the I/O context that are reported with every operation.
+type ioctxObserver struct {
+	observer  observer.Observer
+	pool      string
+	namespace string
+}
+
+// observe informs the observer of the I/O context about a completed
+// operation. It must only be called if the I/O context has an observer.
+func (ioctx *IOContext) observe(name, oid string, bytes int, start time.Time, err error) {
+	if err != nil {
+		bytes = 0
+	}
+	o := ioctx.observer
+	o.observer.ObserveOperation(observer.Operation{
+		Name:      name,
+		Pool:      o.pool,
+		Namespace: o.namespace,
+		Object:    oid,
+		Bytes:     int64(bytes),
+		Duration:  time.Since(start),
+		Err:       err,
+	})
+}
@@ -10,13 +10,12 @@ import "C"
 import (
 	"runtime"
 	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/radostypes"
 )
 
 // OmapKeyValue items are returned by the GetOmapStep's Next call.
-type OmapKeyValue struct {
-	Key   string
-	Value []byte
-}
+type OmapKeyValue = radostypes.OmapKeyValue
 
 // GetOmapStep values are used to get the results of an GetOmapValues call
 // on a WriteOp. Until the Operate method of the WriteOp is called the Next
@@ -107,7 +106,7 @@ func (ioctx *IOContext) SetOmap(oid string, pairs map[string][]byte) error {
 
 // OmapListFunc is the type of the function called for each omap key
 // visited by ListOmapValues
-type OmapListFunc func(key string, value []byte)
+type OmapListFunc = radostypes.OmapListFunc
 
 // ListOmapValues iterates over the keys and values in an omap by way of
 // a callback function.
@@ -4,11 +4,10 @@ package rados
 import "C"
 
 import (
-	"fmt"
-	"strings"
 	"unsafe"
 
 	"github.com/ceph/go-ceph/internal/log"
+	"github.com/ceph/go-ceph/internal/radostypes"
 )
 
 // The file operation.go exists to support both read op and write op types that
@@ -34,27 +33,7 @@ const (
 // OperationError is an error type that may be returned by an Operate call.
 // It captures the error from the operate call itself and any errors from
 // steps that can return an error.
-type OperationError struct {
-	kind       opKind
-	OpError    error
-	StepErrors map[int]error
-}
-
-func (e OperationError) Error() string {
-	subErrors := []string{}
-	if e.OpError != nil {
-		subErrors = append(subErrors,
-			fmt.Sprintf("op=%s", e.OpError))
-	}
-	for idx, es := range e.StepErrors {
-		subErrors = append(subErrors,
-			fmt.Sprintf("Step#%d=%s", idx, es))
-	}
-	return fmt.Sprintf(
-		"%s operation error: %s",
-		e.kind,
-		strings.Join(subErrors, ", "))
-}
+type OperationError = radostypes.OperationError
 
 // opStep provides an interface for types that are tied to the management of
 // data being input or output from write ops and read ops. The steps are
@@ -85,6 +64,19 @@ type opStep interface {
 // both read and write op types.
 type operation struct {
 	steps []opStep
+	// deferRelease, if set, is passed the function that releases the
+	// operation. It returns true if it takes over calling the function,
+	// which is needed while librados may still be using the operation.
+	deferRelease func(release func()) bool
+}
+
+// release calls fn, which releases the operation, unless the release is
+// deferred.
+func (o *operation) release(fn func()) {
+	if o.deferRelease != nil && o.deferRelease(fn) {
+		return
+	}
+	fn()
 }
 
 // free will call the free method of all the steps this operation
@@ -109,11 +101,8 @@ func (o *operation) update(kind opKind, ret C.int) error {
 	if ret == 0 && len(stepErrors) == 0 {
 		return nil
 	}
-	return OperationError{
-		kind:       kind,
-		OpError:    getError(ret),
-		StepErrors: stepErrors,
-	}
+	return radostypes.NewOperationError(
+		string(kind), getError(ret), stepErrors)
 }
 
 func opStepFinalizer(s opStep) {
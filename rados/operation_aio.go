@@ -0,0 +1,80 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// AioOperate will asynchronously perform the operation(s). The WriteOp must
+// not be released, or otherwise used, until the returned Completion is
+// complete. The error of the Completion is the same error that would have
+// been returned by Operate.
+//
+// Implements:
+//
+//	int rados_aio_write_op_operate(rados_write_op_t write_op,
+//	                               rados_ioctx_t io,
+//	                               rados_completion_t completion,
+//	                               const char *oid,
+//	                               time_t *mtime,
+//	                               int flags);
+func (w *WriteOp) AioOperate(ioctx *IOContext, oid string, flags OperationFlags) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	comp, err := newCompletion(
+		func(ret C.int) error { return w.update(writeOp, ret) },
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cOid := C.CString(oid)
+	defer C.free(unsafe.Pointer(cOid))
+
+	ret := C.rados_aio_write_op_operate(
+		w.op, ioctx.ioctx, comp.c, cOid, nil, C.int(flags))
+	return comp.submitted(ret)
+}
+
+// AioOperate will asynchronously perform the operation(s). The ReadOp must
+// not be released, or otherwise used, until the returned Completion is
+// complete. The error of the Completion is the same error that would have
+// been returned by Operate. The results of the steps of the ReadOp are valid
+// once the Completion is complete.
+//
+// Implements:
+//
+//	int rados_aio_read_op_operate(rados_read_op_t read_op,
+//	                              rados_ioctx_t io,
+//	                              rados_completion_t completion,
+//	                              const char *oid,
+//	                              int flags);
+func (r *ReadOp) AioOperate(ioctx *IOContext, oid string, flags OperationFlags) (*Completion, error) {
+	if err := ioctx.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(
+		func(ret C.int) error { return r.update(readOp, ret) },
+		nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cOid := C.CString(oid)
+	defer C.free(unsafe.Pointer(cOid))
+
+	ret := C.rados_aio_read_op_operate(
+		r.op, ioctx.ioctx, comp.c, cOid, C.int(flags))
+	return comp.submitted(ret)
+}
@@ -0,0 +1,76 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestWriteOpAioOperate() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op := CreateWriteOp()
+	defer op.Release()
+	op.Create(CreateExclusive)
+	op.WriteFull([]byte("async write op"))
+	op.SetOmap(map[string][]byte{"key": []byte("value")})
+	comp, err := op.AioOperate(suite.ioctx, oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+
+	// exclusive create on an existing object must fail
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.Create(CreateExclusive)
+	comp, err = op2.AioOperate(suite.ioctx, oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+	err = comp.Wait()
+	ta.Error(err)
+	ta.IsType(OperationError{}, err)
+
+	op3 := CreateWriteOp()
+	defer op3.Release()
+	_, err = op3.AioOperate(&IOContext{}, oid, OperationNoFlag)
+	ta.Equal(ErrInvalidIOContext, err)
+}
+
+func (suite *RadosTestSuite) TestReadOpAioOperate() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	data := []byte("async read op")
+	err := suite.ioctx.WriteFull(oid, data)
+	require.NoError(suite.T(), err)
+	err = suite.ioctx.SetOmap(oid, map[string][]byte{"key": []byte("value")})
+	require.NoError(suite.T(), err)
+
+	buf := make([]byte, 64)
+	op := CreateReadOp()
+	defer op.Release()
+	op.AssertExists()
+	readStep := op.Read(0, buf)
+	omapStep := op.GetOmapValues("", "", 16)
+	comp, err := op.AioOperate(suite.ioctx, oid, OperationNoFlag)
+	require.NoError(suite.T(), err)
+	ta.NoError(comp.Wait())
+	ta.Equal(int64(len(data)), readStep.BytesRead)
+	ta.Equal(data, buf[:readStep.BytesRead])
+	kv, err := omapStep.Next()
+	ta.NoError(err)
+	if ta.NotNil(kv) {
+		ta.Equal("key", kv.Key)
+		ta.Equal([]byte("value"), kv.Value)
+	}
+
+	op2 := CreateReadOp()
+	defer op2.Release()
+	op2.AssertExists()
+	comp, err = op2.AioOperate(suite.ioctx, "does-not-exist", OperationNoFlag)
+	require.NoError(suite.T(), err)
+	ta.Error(comp.Wait())
+}
@@ -0,0 +1,65 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"context"
+	"time"
+)
+
+// OperateContext will perform the operation(s), like Operate, unless the
+// context is done first. In that case ctx.Err() is returned while the
+// operation continues in the cluster and may or may not be applied. The
+// WriteOp must not be used again, apart from calling Release, which is
+// deferred until the operation is complete.
+func (w *WriteOp) OperateContext(ctx context.Context, ioctx *IOContext, oid string, flags OperationFlags) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.WriteOp.OperateContext", oid, 0, start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := w.AioOperate(ioctx, oid, flags)
+	if err != nil {
+		return err
+	}
+	return w.waitContext(ctx, comp)
+}
+
+// OperateContext will perform the operation(s), like Operate, unless the
+// context is done first. In that case ctx.Err() is returned and neither the
+// results of the steps nor the buffers passed to them are updated. The
+// ReadOp must not be used again, apart from calling Release, which is
+// deferred until the operation is complete.
+func (r *ReadOp) OperateContext(ctx context.Context, ioctx *IOContext, oid string, flags OperationFlags) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.ReadOp.OperateContext", oid, 0, start, err)
+		}(time.Now())
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	comp, err := r.AioOperate(ioctx, oid, flags)
+	if err != nil {
+		return err
+	}
+	return r.waitContext(ctx, comp)
+}
+
+// waitContext waits for the Completion of the operation. If the context is
+// done first, the release of the operation is deferred until the Completion
+// is complete, as librados still uses the operation until then.
+func (o *operation) waitContext(ctx context.Context, comp *Completion) error {
+	err := comp.waitContext(ctx)
+	if err != nil && err == ctx.Err() {
+		o.deferRelease = func(release func()) bool {
+			comp.OnComplete(func(*Completion) { release() })
+			return true
+		}
+	}
+	return err
+}
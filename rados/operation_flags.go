@@ -7,8 +7,12 @@ package rados
 //
 import "C"
 
+import (
+	"github.com/ceph/go-ceph/internal/radostypes"
+)
+
 // OperationFlags control the behavior of read and write operations.
-type OperationFlags int
+type OperationFlags = radostypes.OperationFlags
 
 const (
 	// OperationNoFlag indicates no special behavior is requested.
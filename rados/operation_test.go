@@ -7,35 +7,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestOperationError(t *testing.T) {
-	oe := OperationError{
-		kind:    readOp,
-		OpError: fmt.Errorf("bad mojo %v", 77),
-		StepErrors: map[int]error{
-			1: fmt.Errorf("limit exceeded"),
-			3: fmt.Errorf("weirdness"),
-		},
-	}
-	assert.Error(t, oe)
-	estr := oe.Error()
-	assert.Contains(t, estr, "read operation error")
-	assert.Contains(t, estr, "op=bad mojo 77")
-	assert.Contains(t, estr, "Step#1=limit exceeded")
-	assert.Contains(t, estr, "Step#3=weirdness")
-
-	oe = OperationError{
-		kind: writeOp,
-		StepErrors: map[int]error{
-			0: fmt.Errorf("unlucky"),
-		},
-	}
-	assert.Error(t, oe)
-	estr = oe.Error()
-	assert.Contains(t, estr, "write operation error")
-	assert.NotContains(t, estr, "op=")
-	assert.Contains(t, estr, "Step#0=unlucky")
-}
-
 type fooStep struct {
 	updateCount int
 	freeCount   int
@@ -94,6 +65,7 @@ func TestOperationType(t *testing.T) {
 			oe := err.(OperationError)
 			assert.Error(t, oe.OpError)
 			assert.Len(t, oe.StepErrors, 0)
+			assert.Contains(t, oe.Error(), "read operation error")
 		}
 	})
 
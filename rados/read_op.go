@@ -8,6 +8,7 @@ package rados
 import "C"
 
 import (
+	"time"
 	"unsafe"
 )
 
@@ -27,13 +28,21 @@ func CreateReadOp() *ReadOp {
 
 // Release the resources associated with this read operation.
 func (r *ReadOp) Release() {
-	C.rados_release_read_op(r.op)
-	r.op = nil
-	r.free()
+	r.release(func() {
+		C.rados_release_read_op(r.op)
+		r.op = nil
+		r.free()
+	})
 }
 
 // Operate will perform the operation(s).
-func (r *ReadOp) Operate(ioctx *IOContext, oid string, flags OperationFlags) error {
+func (r *ReadOp) Operate(ioctx *IOContext, oid string, flags OperationFlags) (err error) {
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.ReadOp.Operate", oid, 0, start, err)
+		}(time.Now())
+	}
+
 	if err := ioctx.validate(); err != nil {
 		return err
 	}
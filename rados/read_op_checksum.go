@@ -0,0 +1,99 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ReadOpChecksumStep holds the result of the Checksum read operation.
+// Checksums and Result are valid only after Operate() was called.
+type ReadOpChecksumStep struct {
+	// C returned data:
+	checksum    *C.char
+	checksumLen C.size_t
+	prval       *C.int
+
+	checksumType ChecksumType
+
+	// Checksums of the chunks of the object data, in order.
+	Checksums []uint64
+	// Result of this action.
+	Result int
+}
+
+func (s *ReadOpChecksumStep) update() error {
+	s.Result = int(*s.prval)
+	if s.Result != 0 {
+		return nil
+	}
+	sums, err := s.checksumType.parseResult(
+		C.GoBytes(unsafe.Pointer(s.checksum), C.int(s.checksumLen)))
+	if err != nil {
+		return err
+	}
+	s.Checksums = sums
+	return nil
+}
+
+func (s *ReadOpChecksumStep) free() {
+	C.free(unsafe.Pointer(s.checksum))
+	C.free(unsafe.Pointer(s.prval))
+
+	s.checksum = nil
+	s.prval = nil
+}
+
+func newReadOpChecksumStep(t ChecksumType, count int) *ReadOpChecksumStep {
+	s := &ReadOpChecksumStep{
+		checksumLen:  C.size_t(t.resultSize(count)),
+		prval:        (*C.int)(C.malloc(C.sizeof_int)),
+		checksumType: t,
+	}
+	s.checksum = (*C.char)(C.calloc(1, s.checksumLen))
+	*s.prval = 0
+	return s
+}
+
+// Checksum computes checksums of length bytes of the object data starting
+// at byte offset offset as part of the read operation. The data is split
+// into chunks of chunkSize bytes and a checksum is computed for each chunk.
+// If chunkSize is zero a single checksum is computed for the whole range.
+// The init value is used as the initial value (seed) of each checksum. A
+// length of zero selects the data up to the end of the object and may only
+// be used if chunkSize is zero.
+//
+// Implements:
+//
+//	void rados_read_op_checksum(rados_read_op_t read_op,
+//	                            rados_checksum_type_t type,
+//	                            const char *init_value,
+//	                            size_t init_value_len,
+//	                            uint64_t offset, size_t len,
+//	                            size_t chunk_size, char *pchecksum,
+//	                            size_t checksum_len, int *prval);
+func (r *ReadOp) Checksum(t ChecksumType, init, offset, length, chunkSize uint64) *ReadOpChecksumStep {
+	csStep := newReadOpChecksumStep(t, checksumCount(length, chunkSize))
+	r.steps = append(r.steps, csStep)
+
+	initValue := t.initValue(init)
+	C.rados_read_op_checksum(
+		r.op,
+		C.rados_checksum_type_t(t),
+		(*C.char)(unsafe.Pointer(&initValue[0])),
+		C.size_t(len(initValue)),
+		C.uint64_t(offset),
+		C.size_t(length),
+		C.size_t(chunkSize),
+		csStep.checksum,
+		csStep.checksumLen,
+		csStep.prval)
+	return csStep
+}
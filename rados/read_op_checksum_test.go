@@ -0,0 +1,45 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"hash/crc32"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpChecksum() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid  = suite.GenObjectName()
+		data = suite.RandomBytes(8192)
+		err  error
+	)
+
+	err = suite.ioctx.WriteFull(oid, data)
+	ta.NoError(err)
+
+	op := CreateReadOp()
+	defer op.Release()
+	fullStep := op.Checksum(ChecksumCRC32C, 0xffffffff, 0, 0, 0)
+	chunkStep := op.Checksum(ChecksumCRC32C, 0xffffffff, 0, 8192, 4096)
+	xxStep := op.Checksum(ChecksumXXHash64, 0, 0, 8192, 2048)
+	err = op.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// ceph's crc32c does not invert the result like the Go implementation
+	table := crc32.MakeTable(crc32.Castagnoli)
+	crc := func(b []byte) uint64 {
+		return uint64(^crc32.Checksum(b, table))
+	}
+
+	ta.Equal(0, fullStep.Result)
+	ta.Equal([]uint64{crc(data)}, fullStep.Checksums)
+	ta.Equal(0, chunkStep.Result)
+	ta.Equal([]uint64{crc(data[:4096]), crc(data[4096:])}, chunkStep.Checksums)
+	ta.Equal(0, xxStep.Result)
+	ta.Len(xxStep.Checksums, 4)
+}
@@ -0,0 +1,41 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// CmpXattr ensures that the xattr with key name compares to value as
+// required by op before reading. The values are compared as strings. If the
+// comparison fails the operation fails with an error wrapping -ECANCELED.
+//
+// Implements:
+//
+//	void rados_read_op_cmpxattr(rados_read_op_t read_op,
+//	                            const char *name,
+//	                            uint8_t comparison_operator,
+//	                            const char *value,
+//	                            size_t value_len);
+func (r *ReadOp) CmpXattr(name string, op CompareOp, value []byte) {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	var cValue *C.char
+	if len(value) > 0 {
+		cValue = (*C.char)(unsafe.Pointer(&value[0]))
+	}
+
+	C.rados_read_op_cmpxattr(
+		r.op,
+		cName,
+		C.uint8_t(op),
+		cValue,
+		C.size_t(len(value)))
+}
@@ -0,0 +1,50 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpCmpXattr() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid = suite.GenObjectName()
+		err error
+	)
+
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateIdempotent)
+	op1.WriteFull([]byte("guarded data"))
+	op1.SetXattr("state", []byte("ready"))
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// The comparison succeeds, the data is read.
+	readBuf := make([]byte, 64)
+	op2 := CreateReadOp()
+	defer op2.Release()
+	op2.CmpXattr("state", CompareEqual, []byte("ready"))
+	readStep := op2.Read(0, readBuf)
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal([]byte("guarded data"), readBuf[:readStep.BytesRead])
+
+	// The comparison fails, so does the operation.
+	op3 := CreateReadOp()
+	defer op3.Release()
+	op3.CmpXattr("state", CompareEqual, []byte("busy"))
+	op3.Read(0, readBuf)
+	err = op3.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+
+	op4 := CreateReadOp()
+	defer op4.Release()
+	op4.CmpXattr("state", CompareNotEqual, []byte("busy"))
+	err = op4.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+}
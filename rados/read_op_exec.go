@@ -0,0 +1,101 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
+)
+
+// ReadOpExecStep holds the result of the Exec read operation.
+// Result is valid only after Operate() was called.
+type ReadOpExecStep struct {
+	// C returned data:
+	outBuf **C.char
+	outLen *C.size_t
+	prval  *C.int
+
+	Output []byte // Output of the object class method.
+	Result int    // Result of this action.
+}
+
+func (s *ReadOpExecStep) update() error {
+	s.Result = int(*s.prval)
+	if *s.outBuf != nil {
+		s.Output = C.GoBytes(unsafe.Pointer(*s.outBuf), C.int(*s.outLen))
+	}
+	return nil
+}
+
+func (s *ReadOpExecStep) free() {
+	if s.outBuf != nil {
+		C.rados_buffer_free(*s.outBuf)
+		C.free(unsafe.Pointer(s.outBuf))
+	}
+	C.free(unsafe.Pointer(s.outLen))
+	C.free(unsafe.Pointer(s.prval))
+
+	s.outBuf = nil
+	s.outLen = nil
+	s.prval = nil
+}
+
+func newReadOpExecStep() *ReadOpExecStep {
+	s := &ReadOpExecStep{
+		outBuf: (**C.char)(C.malloc(C.size_t(cutil.PtrSize))),
+		outLen: (*C.size_t)(C.malloc(C.sizeof_size_t)),
+		prval:  (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.outBuf = nil
+	*s.outLen = 0
+	return s
+}
+
+// Exec executes the method of an object class (cls) as part of the read
+// operation. The output of the method is available from the returned step
+// after the operation has been performed.
+//
+// Implements:
+//
+//	void rados_read_op_exec(rados_read_op_t read_op,
+//	                        const char *cls,
+//	                        const char *method,
+//	                        const char *in_buf,
+//	                        size_t in_len,
+//	                        char **out_buf,
+//	                        size_t *out_len,
+//	                        int *prval);
+func (r *ReadOp) Exec(cls, method string, in []byte) *ReadOpExecStep {
+	execStep := newReadOpExecStep()
+	r.steps = append(r.steps, execStep)
+
+	cCls := C.CString(cls)
+	defer C.free(unsafe.Pointer(cCls))
+	cMethod := C.CString(method)
+	defer C.free(unsafe.Pointer(cMethod))
+	var cIn *C.char
+	if len(in) > 0 {
+		cIn = (*C.char)(unsafe.Pointer(&in[0]))
+	}
+
+	C.rados_read_op_exec(
+		r.op,
+		cCls,
+		cMethod,
+		cIn,
+		C.size_t(len(in)),
+		execStep.outBuf,
+		execStep.outLen,
+		execStep.prval,
+	)
+
+	return execStep
+}
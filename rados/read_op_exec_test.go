@@ -0,0 +1,37 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestReadOpExec() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	data := []byte("some data")
+	err := suite.ioctx.WriteFull(oid, data)
+	require.NoError(suite.T(), err)
+
+	buf := make([]byte, 64)
+	op := CreateReadOp()
+	defer op.Release()
+	readStep := op.Read(0, buf)
+	execStep := op.Exec("hello", "say_hello", []byte("read op"))
+	err = op.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal(0, execStep.Result)
+	ta.Equal("Hello, read op!", string(execStep.Output))
+	ta.Equal(data, buf[:readStep.BytesRead])
+
+	op2 := CreateReadOp()
+	defer op2.Release()
+	execStep = op2.Exec("hello", "no_such_method", nil)
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+	ta.NotEqual(0, execStep.Result)
+}
@@ -0,0 +1,166 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <errno.h>
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// Xattr items are returned by the ReadOpGetXattrsStep's Next call.
+type Xattr struct {
+	Name  string
+	Value []byte
+}
+
+// ReadOpGetXattrsStep holds the result of the GetXattrs read operation.
+// Until the Operate method of the ReadOp is called the Next call will return
+// an error. After Operate is called, the Next call will return valid results.
+//
+// The life cycle of the ReadOpGetXattrsStep is bound to the ReadOp, if the
+// ReadOp Release method is called the public methods of the step must no
+// longer be used and may return errors.
+type ReadOpGetXattrsStep struct {
+	// C returned data:
+	iter  C.rados_xattrs_iter_t
+	prval *C.int
+
+	// internal state:
+
+	// canIterate is only set after the operation is performed and is
+	// intended to prevent premature fetching of data
+	canIterate bool
+}
+
+func newReadOpGetXattrsStep() *ReadOpGetXattrsStep {
+	s := &ReadOpGetXattrsStep{
+		prval: (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.prval = 0
+	return s
+}
+
+func (s *ReadOpGetXattrsStep) free() {
+	s.canIterate = false
+	if s.iter != nil {
+		C.rados_getxattrs_end(s.iter)
+	}
+	s.iter = nil
+	C.free(unsafe.Pointer(s.prval))
+	s.prval = nil
+}
+
+func (s *ReadOpGetXattrsStep) update() error {
+	err := getError(*s.prval)
+	s.canIterate = (err == nil && s.iter != nil)
+	return err
+}
+
+// Next returns the next xattr of the object or nil if iteration is
+// exhausted.
+func (s *ReadOpGetXattrsStep) Next() (*Xattr, error) {
+	if !s.canIterate {
+		return nil, ErrOperationIncomplete
+	}
+	var (
+		cName *C.char
+		cVal  *C.char
+		cLen  C.size_t
+	)
+	ret := C.rados_getxattrs_next(s.iter, &cName, &cVal, &cLen)
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	// a null name marks the end of the iteration
+	if cName == nil {
+		return nil, nil
+	}
+	return &Xattr{
+		Name:  C.GoString(cName),
+		Value: C.GoBytes(unsafe.Pointer(cVal), C.int(cLen)),
+	}, nil
+}
+
+// GetXattrs is used to iterate over all the xattrs of the object as part of
+// the read operation. The returned ReadOpGetXattrsStep may be used to
+// iterate over the xattrs after the Operate call has been performed.
+//
+// Implements:
+//
+//	void rados_read_op_getxattrs(rados_read_op_t read_op,
+//	                             rados_xattrs_iter_t *iter,
+//	                             int *prval);
+func (r *ReadOp) GetXattrs() *ReadOpGetXattrsStep {
+	s := newReadOpGetXattrsStep()
+	r.steps = append(r.steps, s)
+	C.rados_read_op_getxattrs(r.op, &s.iter, s.prval)
+	return s
+}
+
+// ReadOpGetXattrStep holds the result of the GetXattr read operation.
+// Value and Result are valid only after Operate() was called.
+type ReadOpGetXattrStep struct {
+	xattrs *ReadOpGetXattrsStep
+	name   string
+
+	Value  []byte // Value of the xattr.
+	Result int    // Result of this action.
+}
+
+func (s *ReadOpGetXattrStep) update() error {
+	s.Result = int(*s.xattrs.prval)
+	if s.Result != 0 || s.xattrs.iter == nil {
+		return nil
+	}
+	for {
+		var (
+			cName *C.char
+			cVal  *C.char
+			cLen  C.size_t
+		)
+		ret := C.rados_getxattrs_next(s.xattrs.iter, &cName, &cVal, &cLen)
+		if ret != 0 {
+			s.Result = int(ret)
+			return nil
+		}
+		if cName == nil {
+			s.Result = -C.ENODATA
+			return nil
+		}
+		if C.GoString(cName) == s.name {
+			s.Value = C.GoBytes(unsafe.Pointer(cVal), C.int(cLen))
+			return nil
+		}
+	}
+}
+
+func (s *ReadOpGetXattrStep) free() {
+	s.xattrs.free()
+}
+
+// GetXattr gets the value of the xattr with key name as part of the read
+// operation. librados has no read operation for a single xattr, so all of
+// the xattrs of the object are fetched and the Result of the returned step
+// is set to -ENODATA if the object has no xattr with the given name.
+//
+// Implements:
+//
+//	void rados_read_op_getxattrs(rados_read_op_t read_op,
+//	                             rados_xattrs_iter_t *iter,
+//	                             int *prval);
+func (r *ReadOp) GetXattr(name string) *ReadOpGetXattrStep {
+	s := &ReadOpGetXattrStep{
+		xattrs: newReadOpGetXattrsStep(),
+		name:   name,
+	}
+	r.steps = append(r.steps, s)
+	C.rados_read_op_getxattrs(r.op, &s.xattrs.iter, s.xattrs.prval)
+	return s
+}
@@ -0,0 +1,58 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpGetXattrs() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid = suite.GenObjectName()
+		err error
+	)
+
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateIdempotent)
+	op1.SetXattr("color", []byte("blue"))
+	op1.SetXattr("shape", []byte("square"))
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	op2 := CreateReadOp()
+	defer op2.Release()
+	xattrsStep := op2.GetXattrs()
+	colorStep := op2.GetXattr("color")
+	missingStep := op2.GetXattr("size")
+
+	// iterating before Operate is an error
+	_, err = xattrsStep.Next()
+	ta.Equal(ErrOperationIncomplete, err)
+
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	xattrs := map[string][]byte{}
+	for {
+		xattr, err := xattrsStep.Next()
+		ta.NoError(err)
+		if xattr == nil {
+			break
+		}
+		xattrs[xattr.Name] = xattr.Value
+	}
+	ta.Equal(map[string][]byte{
+		"color": []byte("blue"),
+		"shape": []byte("square"),
+	}, xattrs)
+
+	ta.Equal(0, colorStep.Result)
+	ta.Equal([]byte("blue"), colorStep.Value)
+	ta.Less(missingStep.Result, 0)
+	ta.Nil(missingStep.Value)
+}
@@ -0,0 +1,76 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ReadOpOmapCmpStep holds the result of the OmapCmp read operation.
+// Result is valid only after Operate() was called.
+type ReadOpOmapCmpStep struct {
+	// C returned data:
+	prval *C.int
+
+	// Result of the OmapCmp read operation.
+	Result int
+}
+
+func (s *ReadOpOmapCmpStep) update() error {
+	s.Result = int(*s.prval)
+	return nil
+}
+
+func (s *ReadOpOmapCmpStep) free() {
+	C.free(unsafe.Pointer(s.prval))
+	s.prval = nil
+}
+
+func newReadOpOmapCmpStep() *ReadOpOmapCmpStep {
+	s := &ReadOpOmapCmpStep{
+		prval: (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.prval = 0
+	return s
+}
+
+// OmapCmp ensures that the value of the omap key compares to value as
+// required by op before reading. The value stored in the omap is the first
+// operand of the comparison. If the comparison fails the operation fails
+// with an error wrapping -ECANCELED.
+//
+// Implements:
+//
+//	void rados_read_op_omap_cmp(rados_read_op_t read_op,
+//	                            const char *key,
+//	                            uint8_t comparison_operator,
+//	                            const char *val,
+//	                            size_t val_len,
+//	                            int *prval);
+func (r *ReadOp) OmapCmp(key string, op CompareOp, value []byte) *ReadOpOmapCmpStep {
+	cmpStep := newReadOpOmapCmpStep()
+	r.steps = append(r.steps, cmpStep)
+
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	var cValue *C.char
+	if len(value) > 0 {
+		cValue = (*C.char)(unsafe.Pointer(&value[0]))
+	}
+
+	C.rados_read_op_omap_cmp(
+		r.op,
+		cKey,
+		C.uint8_t(op),
+		cValue,
+		C.size_t(len(value)),
+		cmpStep.prval)
+	return cmpStep
+}
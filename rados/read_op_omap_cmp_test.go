@@ -0,0 +1,47 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpOmapCmp() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid = suite.GenObjectName()
+		err error
+	)
+
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateIdempotent)
+	op1.SetOmap(map[string][]byte{
+		"owner": []byte("alice"),
+		"count": []byte("5"),
+	})
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// The comparison succeeds, the omap is read.
+	op2 := CreateReadOp()
+	defer op2.Release()
+	cmpStep := op2.OmapCmp("owner", CompareEqual, []byte("alice"))
+	valsStep := op2.GetOmapValuesByKeys([]string{"count"})
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal(0, cmpStep.Result)
+	kv, err := valsStep.Next()
+	ta.NoError(err)
+	ta.Equal(&OmapKeyValue{Key: "count", Value: []byte("5")}, kv)
+
+	// The comparison fails, so does the operation.
+	op3 := CreateReadOp()
+	defer op3.Release()
+	op3.OmapCmp("owner", CompareEqual, []byte("bob"))
+	err = op3.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+}
@@ -0,0 +1,122 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// ReadOpOmapGetKeysStep holds the result of the GetOmapKeys read operation.
+// Until the Operate method of the ReadOp is called the Next call will return
+// an error. After Operate is called, the Next call will return valid results.
+//
+// The life cycle of the ReadOpOmapGetKeysStep is bound to the ReadOp, if the
+// ReadOp Release method is called the public methods of the step must no
+// longer be used and may return errors.
+type ReadOpOmapGetKeysStep struct {
+	// C returned data:
+	iter  C.rados_omap_iter_t
+	more  *C.uchar
+	prval *C.int
+
+	// internal state:
+
+	// canIterate is only set after the operation is performed and is
+	// intended to prevent premature fetching of data
+	canIterate bool
+}
+
+func newReadOpOmapGetKeysStep() *ReadOpOmapGetKeysStep {
+	s := &ReadOpOmapGetKeysStep{
+		more:  (*C.uchar)(C.malloc(C.sizeof_uchar)),
+		prval: (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.more = 0
+	*s.prval = 0
+	return s
+}
+
+func (s *ReadOpOmapGetKeysStep) free() {
+	s.canIterate = false
+	if s.iter != nil {
+		C.rados_omap_get_end(s.iter)
+	}
+	s.iter = nil
+	C.free(unsafe.Pointer(s.more))
+	s.more = nil
+	C.free(unsafe.Pointer(s.prval))
+	s.prval = nil
+}
+
+func (s *ReadOpOmapGetKeysStep) update() error {
+	err := getError(*s.prval)
+	s.canIterate = (err == nil && s.iter != nil)
+	return err
+}
+
+// Next returns the next omap key or nil if iteration is exhausted. Only the
+// Key field of the returned OmapKeyValue is set.
+func (s *ReadOpOmapGetKeysStep) Next() (*OmapKeyValue, error) {
+	if !s.canIterate {
+		return nil, ErrOperationIncomplete
+	}
+	var (
+		cKey    *C.char
+		cVal    *C.char
+		cKeyLen C.size_t
+		cValLen C.size_t
+	)
+	ret := C.rados_omap_get_next2(s.iter, &cKey, &cVal, &cKeyLen, &cValLen)
+	if ret != 0 {
+		return nil, getError(ret)
+	}
+	if cKey == nil {
+		return nil, nil
+	}
+	return &OmapKeyValue{
+		Key: string(C.GoBytes(unsafe.Pointer(cKey), C.int(cKeyLen))),
+	}, nil
+}
+
+// More returns true if there are more matching keys available.
+func (s *ReadOpOmapGetKeysStep) More() bool {
+	return *s.more != 0
+}
+
+// GetOmapKeys is used to iterate over a set, or sub-set, of omap keys,
+// without their values, as part of a read operation. The returned
+// ReadOpOmapGetKeysStep may be used to iterate over the keys after the
+// Operate call has been performed.
+//
+// Implements:
+//
+//	void rados_read_op_omap_get_keys2(rados_read_op_t read_op,
+//	                                  const char *start_after,
+//	                                  uint64_t max_return,
+//	                                  rados_omap_iter_t *iter,
+//	                                  unsigned char *pmore,
+//	                                  int *prval);
+func (r *ReadOp) GetOmapKeys(startAfter string, maxReturn uint64) *ReadOpOmapGetKeysStep {
+	s := newReadOpOmapGetKeysStep()
+	r.steps = append(r.steps, s)
+
+	cStartAfter := C.CString(startAfter)
+	defer C.free(unsafe.Pointer(cStartAfter))
+
+	C.rados_read_op_omap_get_keys2(
+		r.op,
+		cStartAfter,
+		C.uint64_t(maxReturn),
+		&s.iter,
+		s.more,
+		s.prval,
+	)
+	return s
+}
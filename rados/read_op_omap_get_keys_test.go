@@ -0,0 +1,47 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpGetOmapKeys() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid = suite.GenObjectName()
+		err error
+	)
+
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateIdempotent)
+	op1.SetOmap(map[string][]byte{
+		"a": []byte("1"),
+		"b": []byte("2"),
+		"c": []byte("3"),
+	})
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	op2 := CreateReadOp()
+	defer op2.Release()
+	keysStep := op2.GetOmapKeys("a", 1)
+
+	_, err = keysStep.Next()
+	ta.Equal(ErrOperationIncomplete, err)
+
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	kv, err := keysStep.Next()
+	ta.NoError(err)
+	ta.Equal(&OmapKeyValue{Key: "b"}, kv)
+	kv, err = keysStep.Next()
+	ta.NoError(err)
+	ta.Nil(kv)
+	ta.True(keysStep.More())
+}
@@ -37,10 +37,12 @@ func (s *ReadOpReadStep) free() {
 }
 
 func newReadOpReadStep() *ReadOpReadStep {
-	return &ReadOpReadStep{
+	s := &ReadOpReadStep{
 		bytesRead: (*C.size_t)(C.malloc(C.sizeof_size_t)),
 		prval:     (*C.int)(C.malloc(C.sizeof_int)),
 	}
+	*s.bytesRead = 0
+	return s
 }
 
 // Read bytes from offset into buffer.
@@ -56,8 +58,8 @@ func newReadOpReadStep() *ReadOpReadStep {
 //	                        size_t * bytes_read,
 //	                        int * prval)
 func (r *ReadOp) Read(offset uint64, buffer []byte) *ReadOpReadStep {
-	oe := newReadStep(buffer, offset)
 	readStep := newReadOpReadStep()
+	oe := newReadStep(buffer, offset, readStep.bytesRead)
 	r.steps = append(r.steps, oe, readStep)
 	C.rados_read_op_read(
 		r.op,
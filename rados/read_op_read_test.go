@@ -1,9 +1,29 @@
 package rados
 
 import (
+	"testing"
+	"unsafe"
+
 	"github.com/stretchr/testify/assert"
 )
 
+func TestReadStepUpdate(t *testing.T) {
+	buf := []byte("........")
+	rs := newReadOpReadStep()
+	s := newReadStep(buf, 0, rs.bytesRead)
+	defer s.free()
+	defer rs.free()
+
+	// librados writes to the C buffer only
+	cBuf := unsafe.Slice((*byte)(unsafe.Pointer(s.cBuffer)), len(buf))
+	copy(cBuf, "data")
+	*(*uintptr)(unsafe.Pointer(rs.bytesRead)) = 4
+	assert.Equal(t, "........", string(buf))
+
+	assert.NoError(t, s.update())
+	assert.Equal(t, "data....", string(buf))
+}
+
 func (suite *RadosTestSuite) TestReadOpRead() {
 	suite.SetupConnection()
 	ta := assert.New(suite.T())
@@ -0,0 +1,78 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"time"
+	"unsafe"
+)
+
+// ReadOpStatStep holds the result of the Stat read operation.
+// Result is valid only after Operate() was called.
+type ReadOpStatStep struct {
+	// C returned data:
+	psize  *C.uint64_t
+	pmtime *C.time_t
+	prval  *C.int
+
+	Size    uint64    // Size of the object.
+	ModTime time.Time // Last modification time of the object.
+	Result  int       // Result of this action.
+}
+
+func (s *ReadOpStatStep) update() error {
+	s.Result = int(*s.prval)
+	if s.Result == 0 {
+		s.Size = uint64(*s.psize)
+		s.ModTime = time.Unix(int64(*s.pmtime), 0)
+	}
+	return nil
+}
+
+func (s *ReadOpStatStep) free() {
+	C.free(unsafe.Pointer(s.psize))
+	C.free(unsafe.Pointer(s.pmtime))
+	C.free(unsafe.Pointer(s.prval))
+
+	s.psize = nil
+	s.pmtime = nil
+	s.prval = nil
+}
+
+func newReadOpStatStep() *ReadOpStatStep {
+	s := &ReadOpStatStep{
+		psize:  (*C.uint64_t)(C.malloc(C.sizeof_uint64_t)),
+		pmtime: (*C.time_t)(C.malloc(C.sizeof_time_t)),
+		prval:  (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.prval = 0
+	return s
+}
+
+// Stat gets the size and the last modification time of the object as part
+// of the read operation.
+//
+// Implements:
+//
+//	void rados_read_op_stat(rados_read_op_t read_op,
+//	                        uint64_t *psize,
+//	                        time_t *pmtime,
+//	                        int *prval);
+func (r *ReadOp) Stat() *ReadOpStatStep {
+	statStep := newReadOpStatStep()
+	r.steps = append(r.steps, statStep)
+	C.rados_read_op_stat(
+		r.op,
+		statStep.psize,
+		statStep.pmtime,
+		statStep.prval,
+	)
+	return statStep
+}
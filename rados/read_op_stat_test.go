@@ -0,0 +1,50 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestReadOpStat() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	var (
+		oid  = suite.GenObjectName()
+		data = []byte("data to stat")
+		err  error
+	)
+
+	before := time.Now().Add(-time.Minute)
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateIdempotent)
+	op1.WriteFull(data)
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// Stat and read the object in a single operation.
+	readBuf := make([]byte, 64)
+	op2 := CreateReadOp()
+	defer op2.Release()
+	statStep := op2.Stat()
+	readStep := op2.Read(0, readBuf)
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal(0, statStep.Result)
+	ta.Equal(uint64(len(data)), statStep.Size)
+	ta.True(statStep.ModTime.After(before))
+	ta.Equal(data, readBuf[:readStep.BytesRead])
+
+	// Stat a missing object.
+	op3 := CreateReadOp()
+	defer op3.Release()
+	op3.Stat()
+	err = op3.Operate(suite.ioctx, suite.GenObjectName(), OperationNoFlag)
+	ta.Error(err)
+	ta.Equal(ErrNotFound, err.(OperationError).OpError)
+}
@@ -1,19 +1,24 @@
 package rados
 
 // #include <stdint.h>
+// #include <stdlib.h>
 import "C"
 
 import (
 	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
 )
 
+// readStep manages the buffer of a read action. librados reads into C
+// memory, which is copied to the Go byteslice when the operation is
+// updated, so that librados never writes to Go memory. Thus the byteslice
+// is left untouched if the operation is abandoned before it is complete.
 type readStep struct {
-	withoutUpdate
-	withoutFree
-	// the c pointer utilizes the Go byteslice data and no free is needed
-
 	// inputs:
 	b []byte
+	// bytesRead is set by librados to the number of bytes read
+	bytesRead *C.size_t
 
 	// arguments:
 	cBuffer  *C.char
@@ -21,11 +26,31 @@ type readStep struct {
 	cOffset  C.uint64_t
 }
 
-func newReadStep(b []byte, offset uint64) *readStep {
+func newReadStep(b []byte, offset uint64, bytesRead *C.size_t) *readStep {
 	return &readStep{
-		b:        b,
-		cBuffer:  (*C.char)(unsafe.Pointer(&b[0])), // TODO: must be pinned
-		cReadLen: C.size_t(len(b)),
-		cOffset:  C.uint64_t(offset),
+		b:         b,
+		bytesRead: bytesRead,
+		cBuffer:   (*C.char)(C.malloc(C.size_t(len(b)))),
+		cReadLen:  C.size_t(len(b)),
+		cOffset:   C.uint64_t(offset),
+	}
+}
+
+func (s *readStep) update() error {
+	n := *s.bytesRead
+	if n > s.cReadLen {
+		n = s.cReadLen
+	}
+	if n > 0 {
+		cutil.Memcpy(
+			cutil.CPtr(unsafe.Pointer(&s.b[0])),
+			cutil.CPtr(s.cBuffer),
+			cutil.SizeT(n))
 	}
+	return nil
+}
+
+func (s *readStep) free() {
+	C.free(unsafe.Pointer(s.cBuffer))
+	s.cBuffer = nil
 }
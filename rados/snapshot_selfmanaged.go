@@ -0,0 +1,113 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <stdlib.h>
+// #include <rados/librados.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// SnapContext is the snapshot context that is used when writing to objects
+// in a pool that uses self-managed snapshots. Seq is the most recent snapshot
+// sequence number and Snaps contains the IDs of all existing snapshots, in
+// descending order.
+type SnapContext struct {
+	Seq   SnapID
+	Snaps []SnapID
+}
+
+// CreateSelfManagedSnap allocates a new self-managed snapshot ID for the
+// pool. A pool can not use both pool snapshots and self-managed snapshots.
+//
+// Implements:
+//
+//	int rados_ioctx_selfmanaged_snap_create(rados_ioctx_t io,
+//	                                        rados_snap_t *snapid);
+func (ioctx *IOContext) CreateSelfManagedSnap() (SnapID, error) {
+	var snapID SnapID
+
+	if err := ioctx.validate(); err != nil {
+		return snapID, err
+	}
+
+	ret := C.rados_ioctx_selfmanaged_snap_create(
+		ioctx.ioctx,
+		(*C.rados_snap_t)(&snapID))
+	return snapID, getError(ret)
+}
+
+// RemoveSelfManagedSnap removes the self-managed snapshot with the given ID
+// from the pool.
+//
+// Implements:
+//
+//	int rados_ioctx_selfmanaged_snap_remove(rados_ioctx_t io,
+//	                                        rados_snap_t snapid);
+func (ioctx *IOContext) RemoveSelfManagedSnap(snapID SnapID) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	ret := C.rados_ioctx_selfmanaged_snap_remove(
+		ioctx.ioctx,
+		(C.rados_snap_t)(snapID))
+	return getError(ret)
+}
+
+// RollbackSelfManagedSnap rolls back the object with key oid to the
+// self-managed snapshot with the given ID. The contents of the object will
+// be the same as when the snapshot was taken.
+//
+// Implements:
+//
+//	int rados_ioctx_selfmanaged_snap_rollback(rados_ioctx_t io,
+//	                                          const char *oid,
+//	                                          rados_snap_t snapid);
+func (ioctx *IOContext) RollbackSelfManagedSnap(oid string, snapID SnapID) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	coid := C.CString(oid)
+	defer C.free(unsafe.Pointer(coid))
+
+	ret := C.rados_ioctx_selfmanaged_snap_rollback(
+		ioctx.ioctx,
+		coid,
+		(C.rados_snap_t)(snapID))
+	return getError(ret)
+}
+
+// SetSelfManagedSnapWriteContext sets the snapshot context used for all
+// writes performed with the IOContext. Objects written with a snapshot
+// context are cloned, preserving their contents for the snapshots listed in
+// the context, before they are modified.
+//
+// Implements:
+//
+//	int rados_ioctx_selfmanaged_snap_set_write_ctx(rados_ioctx_t io,
+//	                                               rados_snap_t seq,
+//	                                               rados_snap_t *snaps,
+//	                                               int num_snaps);
+func (ioctx *IOContext) SetSelfManagedSnapWriteContext(snapCtx SnapContext) error {
+	if err := ioctx.validate(); err != nil {
+		return err
+	}
+
+	var cSnaps *C.rados_snap_t
+	if len(snapCtx.Snaps) > 0 {
+		cSnaps = (*C.rados_snap_t)(unsafe.Pointer(&snapCtx.Snaps[0]))
+	}
+
+	ret := C.rados_ioctx_selfmanaged_snap_set_write_ctx(
+		ioctx.ioctx,
+		(C.rados_snap_t)(snapCtx.Seq),
+		cSnaps,
+		C.int(len(snapCtx.Snaps)))
+	return getError(ret)
+}
@@ -0,0 +1,95 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestSelfManagedSnapshots() {
+	suite.SetupConnection()
+
+	// self-managed snapshots can not be used in a pool that has ever had
+	// pool snapshots, so these tests get a pool of their own
+	pool := uuid.Must(uuid.NewV4()).String()
+	err := suite.conn.MakePool(pool)
+	require.NoError(suite.T(), err)
+	defer func() {
+		assert.NoError(suite.T(), suite.conn.DeletePool(pool))
+	}()
+	ioctx, err := suite.conn.OpenIOContext(pool)
+	require.NoError(suite.T(), err)
+	defer ioctx.Destroy()
+
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		ioctx := &IOContext{}
+		_, err := ioctx.CreateSelfManagedSnap()
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = ioctx.RemoveSelfManagedSnap(SnapID(1))
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = ioctx.RollbackSelfManagedSnap("foo", SnapID(1))
+		assert.Equal(t, ErrInvalidIOContext, err)
+		err = ioctx.SetSelfManagedSnapWriteContext(SnapContext{})
+		assert.Equal(t, ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("createRemove", func(t *testing.T) {
+		snapID, err := ioctx.CreateSelfManagedSnap()
+		assert.NoError(t, err)
+		err = ioctx.RemoveSelfManagedSnap(snapID)
+		assert.NoError(t, err)
+	})
+
+	suite.T().Run("readWriteRollback", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		err := ioctx.WriteFull(oid, []byte("version one"))
+		require.NoError(t, err)
+
+		snapID, err := ioctx.CreateSelfManagedSnap()
+		require.NoError(t, err)
+		defer func() {
+			assert.NoError(t, ioctx.RemoveSelfManagedSnap(snapID))
+		}()
+
+		err = ioctx.SetSelfManagedSnapWriteContext(SnapContext{
+			Seq:   snapID,
+			Snaps: []SnapID{snapID},
+		})
+		require.NoError(t, err)
+		err = ioctx.WriteFull(oid, []byte("version two"))
+		require.NoError(t, err)
+
+		buf := make([]byte, 64)
+		n, err := ioctx.Read(oid, buf, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "version two", string(buf[:n]))
+
+		err = ioctx.SetReadSnap(snapID)
+		assert.NoError(t, err)
+		n, err = ioctx.Read(oid, buf, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "version one", string(buf[:n]))
+		err = ioctx.SetReadSnap(SnapHead)
+		assert.NoError(t, err)
+
+		err = ioctx.RollbackSelfManagedSnap(oid, snapID)
+		assert.NoError(t, err)
+		n, err = ioctx.Read(oid, buf, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "version one", string(buf[:n]))
+	})
+
+	suite.T().Run("invalidWriteContext", func(t *testing.T) {
+		// snapshot IDs must be in descending order
+		err := ioctx.SetSelfManagedSnapWriteContext(SnapContext{
+			Seq:   SnapID(2),
+			Snaps: []SnapID{SnapID(1), SnapID(2)},
+		})
+		assert.Error(t, err)
+	})
+}
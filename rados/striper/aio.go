@@ -0,0 +1,238 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package striper
+
+// #cgo LDFLAGS: -lrados -lradosstriper
+// #include <stdlib.h>
+// #include <radosstriper/libradosstriper.h>
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
+	"github.com/ceph/go-ceph/rados"
+)
+
+// AioWrite asynchronously writes len(data) bytes to the striped object with
+// key soid starting at byte offset offset. The data is copied before
+// AioWrite returns.
+//
+// Implements:
+//
+//	int rados_striper_aio_write(rados_striper_t striper, const char *soid,
+//	                            rados_completion_t completion,
+//	                            const char *buf, size_t len, uint64_t off);
+func (s *Striper) AioWrite(soid string, data []byte, offset uint64) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_write(
+		s.striper,
+		cSoid,
+		comp.c,
+		bufPtr(data),
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	return comp.submitted(ret)
+}
+
+// AioWriteFull asynchronously writes len(data) bytes to the striped object
+// with key soid, replacing any previous contents of the object. The data is
+// copied before AioWriteFull returns.
+//
+// Implements:
+//
+//	int rados_striper_aio_write_full(rados_striper_t striper,
+//	                                 const char *soid,
+//	                                 rados_completion_t completion,
+//	                                 const char *buf, size_t len);
+func (s *Striper) AioWriteFull(soid string, data []byte) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_write_full(
+		s.striper,
+		cSoid,
+		comp.c,
+		bufPtr(data),
+		C.size_t(len(data)))
+	return comp.submitted(ret)
+}
+
+// AioAppend asynchronously appends len(data) bytes to the striped object
+// with key soid. The data is copied before AioAppend returns.
+//
+// Implements:
+//
+//	int rados_striper_aio_append(rados_striper_t striper, const char *soid,
+//	                             rados_completion_t completion,
+//	                             const char *buf, size_t len);
+func (s *Striper) AioAppend(soid string, data []byte) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_append(
+		s.striper,
+		cSoid,
+		comp.c,
+		bufPtr(data),
+		C.size_t(len(data)))
+	return comp.submitted(ret)
+}
+
+// AioRead asynchronously reads up to len(data) bytes from the striped object
+// with key soid starting at byte offset offset. The data slice must not be
+// accessed until the returned Completion is complete. The number of bytes
+// read is returned by the Completion's Result method.
+//
+// Implements:
+//
+//	int rados_striper_aio_read(rados_striper_t striper, const char *soid,
+//	                           rados_completion_t completion,
+//	                           char *buf, const size_t len, uint64_t off);
+func (s *Striper) AioRead(soid string, data []byte, offset uint64) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrEmptyArgument
+	}
+
+	// libradosstriper writes to the buffer after this call returns, so we
+	// have to use C memory and copy the result to the Go buffer on completion
+	cBuf := C.malloc(C.size_t(len(data)))
+	comp, err := newCompletion(
+		func(ret C.int) error {
+			if ret > 0 {
+				cutil.Memcpy(
+					cutil.CPtr(unsafe.Pointer(&data[0])),
+					cutil.CPtr(cBuf),
+					cutil.SizeT(ret))
+			}
+			return getErrorIfNegative(ret)
+		},
+		func() { C.free(cBuf) })
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_read(
+		s.striper,
+		cSoid,
+		comp.c,
+		(*C.char)(cBuf),
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	return comp.submitted(ret)
+}
+
+// AioRemove asynchronously deletes the striped object with key soid.
+//
+// Implements:
+//
+//	int rados_striper_aio_remove(rados_striper_t striper, const char* soid,
+//	                             rados_completion_t completion);
+func (s *Striper) AioRemove(soid string) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	comp, err := newCompletion(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_remove(s.striper, cSoid, comp.c)
+	return comp.submitted(ret)
+}
+
+// AioStat asynchronously gets the size and the last modification time of the
+// striped object with key soid. The stat value is filled in when the
+// returned Completion is complete and must not be accessed before.
+//
+// Implements:
+//
+//	int rados_striper_aio_stat(rados_striper_t striper, const char* soid,
+//	                           rados_completion_t completion,
+//	                           uint64_t *psize, time_t *pmtime);
+func (s *Striper) AioStat(soid string, stat *rados.ObjectStat) (*Completion, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	if stat == nil {
+		return nil, ErrEmptyArgument
+	}
+
+	cSize := (*C.uint64_t)(C.malloc(C.sizeof_uint64_t))
+	cMtime := (*C.time_t)(C.malloc(C.sizeof_time_t))
+	comp, err := newCompletion(
+		func(ret C.int) error {
+			if ret < 0 {
+				return getError(ret)
+			}
+			*stat = rados.ObjectStat{
+				Size:    uint64(*cSize),
+				ModTime: time.Unix(int64(*cMtime), 0),
+			}
+			return nil
+		},
+		func() {
+			C.free(unsafe.Pointer(cSize))
+			C.free(unsafe.Pointer(cMtime))
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_aio_stat(s.striper, cSoid, comp.c, cSize, cMtime)
+	return comp.submitted(ret)
+}
+
+// AioFlush blocks until all pending asynchronous writes of the Striper are
+// complete.
+//
+// Implements:
+//
+//	void rados_striper_aio_flush(rados_striper_t striper);
+func (s *Striper) AioFlush() error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	C.rados_striper_aio_flush(s.striper)
+	return nil
+}
@@ -0,0 +1,8 @@
+/*
+Package striper contains a set of wrappers around Ceph's libradosstriper API.
+
+The striper stores large objects by splitting their data across multiple
+RADOS objects. Objects written with this package are compatible with the
+objects accessed by the `rados --striper` command line tool.
+*/
+package striper
@@ -0,0 +1,70 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package striper
+
+/*
+#include <errno.h>
+*/
+import "C"
+
+import (
+	"errors"
+
+	"github.com/ceph/go-ceph/internal/errutil"
+)
+
+// striperError represents an error condition returned from the
+// libradosstriper APIs.
+type striperError int
+
+// Error returns the error string for the striperError type.
+func (e striperError) Error() string {
+	return errutil.FormatErrorCode("radosstriper", int(e))
+}
+
+func (e striperError) ErrorCode() int {
+	return int(e)
+}
+
+func getError(e C.int) error {
+	if e == 0 {
+		return nil
+	}
+	return striperError(e)
+}
+
+// getErrorIfNegative converts a ceph return code to error if negative.
+// This is useful for functions that return a usable positive value on
+// success but a negative error number on error.
+func getErrorIfNegative(ret C.int) error {
+	if ret >= 0 {
+		return nil
+	}
+	return getError(ret)
+}
+
+// Public go errors:
+
+var (
+	// ErrInvalidStriper may be returned if an api call requires a Striper
+	// but the Striper is not ready for use.
+	ErrInvalidStriper = errors.New("Striper is not ready for use")
+	// ErrEmptyArgument may be returned if a function argument is passed
+	// a zero-length slice or map.
+	ErrEmptyArgument = errors.New("Argument must contain at least one item")
+	// ErrOperationIncomplete is returned from a Completion for which the
+	// asynchronous operation has not been completed yet.
+	ErrOperationIncomplete = errors.New("Operation has not been performed yet")
+)
+
+// Public striperErrors:
+
+const (
+	// ErrNotFound indicates a missing resource.
+	ErrNotFound = striperError(-C.ENOENT)
+	// ErrPermissionDenied indicates a permissions issue.
+	ErrPermissionDenied = striperError(-C.EPERM)
+	// ErrObjectExists indicates that an exclusive object creation failed.
+	ErrObjectExists = striperError(-C.EEXIST)
+)
@@ -0,0 +1,288 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package striper
+
+// #cgo LDFLAGS: -lrados -lradosstriper
+// #include <stdlib.h>
+// #include <radosstriper/libradosstriper.h>
+import "C"
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+// Striper is used to access striped objects within the pool of an
+// IOContext.
+type Striper struct {
+	striper C.rados_striper_t
+
+	// Hold a reference back to the IOContext the striper depends on.
+	ioctx *rados.IOContext
+}
+
+// cephIoctx returns a ceph rados_ioctx_t given a go-ceph rados IOContext.
+func cephIoctx(radosIoctx *rados.IOContext) C.rados_ioctx_t {
+	p := radosIoctx.Pointer()
+	if p == nil {
+		panic("invalid IOContext pointer")
+	}
+	return C.rados_ioctx_t(p)
+}
+
+// New returns a Striper for the pool of the given IOContext. The IOContext
+// must not be destroyed before the Striper. If the IOContext is not ready for
+// use rados.ErrInvalidIOContext is returned.
+//
+// Implements:
+//
+//	int rados_striper_create(rados_ioctx_t ioctx,
+//	                         rados_striper_t *striper);
+func New(ioctx *rados.IOContext) (*Striper, error) {
+	if ioctx == nil || ioctx.Pointer() == nil {
+		return nil, rados.ErrInvalidIOContext
+	}
+	s := &Striper{ioctx: ioctx}
+	ret := C.rados_striper_create(cephIoctx(ioctx), &s.striper)
+	if err := getError(ret); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// validate returns an error if the striper is not ready to be used
+// with ceph C calls.
+func (s *Striper) validate() error {
+	if s.striper == nil {
+		return ErrInvalidStriper
+	}
+	return nil
+}
+
+// Destroy releases the resources of the Striper. The Striper must not be
+// used after calling this method.
+//
+// Implements:
+//
+//	void rados_striper_destroy(rados_striper_t striper);
+func (s *Striper) Destroy() {
+	if s.striper != nil {
+		C.rados_striper_destroy(s.striper)
+		s.striper = nil
+	}
+}
+
+// SetObjectLayoutStripeUnit sets the stripe unit, in bytes, used for
+// objects created by the Striper.
+//
+// Implements:
+//
+//	int rados_striper_set_object_layout_stripe_unit(rados_striper_t striper,
+//	                                                unsigned int stripe_unit);
+func (s *Striper) SetObjectLayoutStripeUnit(stripeUnit uint) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	ret := C.rados_striper_set_object_layout_stripe_unit(
+		s.striper, C.uint(stripeUnit))
+	return getError(ret)
+}
+
+// SetObjectLayoutStripeCount sets the number of RADOS objects data is
+// striped across for objects created by the Striper.
+//
+// Implements:
+//
+//	int rados_striper_set_object_layout_stripe_count(rados_striper_t striper,
+//	                                                 unsigned int stripe_count);
+func (s *Striper) SetObjectLayoutStripeCount(stripeCount uint) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	ret := C.rados_striper_set_object_layout_stripe_count(
+		s.striper, C.uint(stripeCount))
+	return getError(ret)
+}
+
+// SetObjectLayoutObjectSize sets the maximum size, in bytes, of the RADOS
+// objects used for objects created by the Striper. The object size must be
+// a multiple of the stripe unit.
+//
+// Implements:
+//
+//	int rados_striper_set_object_layout_object_size(rados_striper_t striper,
+//	                                                unsigned int object_size);
+func (s *Striper) SetObjectLayoutObjectSize(objectSize uint) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	ret := C.rados_striper_set_object_layout_object_size(
+		s.striper, C.uint(objectSize))
+	return getError(ret)
+}
+
+// Write writes len(data) bytes to the striped object with key soid starting
+// at byte offset offset.
+//
+// Implements:
+//
+//	int rados_striper_write(rados_striper_t striper, const char *soid,
+//	                        const char *buf, size_t len, uint64_t off);
+func (s *Striper) Write(soid string, data []byte, offset uint64) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_write(
+		s.striper,
+		cSoid,
+		bufPtr(data),
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	return getError(ret)
+}
+
+// WriteFull writes len(data) bytes to the striped object with key soid,
+// replacing any previous contents of the object.
+//
+// Implements:
+//
+//	int rados_striper_write_full(rados_striper_t striper, const char *soid,
+//	                             const char *buf, size_t len);
+func (s *Striper) WriteFull(soid string, data []byte) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_write_full(
+		s.striper,
+		cSoid,
+		bufPtr(data),
+		C.size_t(len(data)))
+	return getError(ret)
+}
+
+// Append appends len(data) bytes to the striped object with key soid.
+//
+// Implements:
+//
+//	int rados_striper_append(rados_striper_t striper, const char *soid,
+//	                         const char *buf, size_t len);
+func (s *Striper) Append(soid string, data []byte) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_append(
+		s.striper,
+		cSoid,
+		bufPtr(data),
+		C.size_t(len(data)))
+	return getError(ret)
+}
+
+// Read reads up to len(data) bytes from the striped object with key soid
+// starting at byte offset offset. It returns the number of bytes read.
+//
+// Implements:
+//
+//	int rados_striper_read(rados_striper_t striper, const char *soid,
+//	                       char *buf, size_t len, uint64_t off);
+func (s *Striper) Read(soid string, data []byte, offset uint64) (int, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	ret := C.rados_striper_read(
+		s.striper,
+		cSoid,
+		bufPtr(data),
+		C.size_t(len(data)),
+		C.uint64_t(offset))
+	if ret >= 0 {
+		return int(ret), nil
+	}
+	return 0, getError(ret)
+}
+
+// Remove deletes the striped object with key soid and all of the RADOS
+// objects backing it.
+//
+// Implements:
+//
+//	int rados_striper_remove(rados_striper_t striper, const char* soid);
+func (s *Striper) Remove(soid string) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	return getError(C.rados_striper_remove(s.striper, cSoid))
+}
+
+// Truncate resizes the striped object with key soid to size size. If the
+// operation enlarges the object, the new area is logically filled with
+// zeroes. If the operation shrinks the object, the excess data is removed.
+//
+// Implements:
+//
+//	int rados_striper_trunc(rados_striper_t striper, const char *soid,
+//	                        uint64_t size);
+func (s *Striper) Truncate(soid string, size uint64) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	return getError(C.rados_striper_trunc(s.striper, cSoid, C.uint64_t(size)))
+}
+
+// Stat returns the size of the striped object with key soid and its last
+// modification time.
+//
+// Implements:
+//
+//	int rados_striper_stat(rados_striper_t striper, const char* soid,
+//	                       uint64_t *psize, time_t *pmtime);
+func (s *Striper) Stat(soid string) (rados.ObjectStat, error) {
+	if err := s.validate(); err != nil {
+		return rados.ObjectStat{}, err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	var (
+		cSize  C.uint64_t
+		cMtime C.time_t
+	)
+	ret := C.rados_striper_stat(s.striper, cSoid, &cSize, &cMtime)
+	if ret < 0 {
+		return rados.ObjectStat{}, getError(ret)
+	}
+	return rados.ObjectStat{
+		Size:    uint64(cSize),
+		ModTime: time.Unix(int64(cMtime), 0),
+	}, nil
+}
+
+// bufPtr returns a C pointer to the first byte of data or nil if data is
+// empty.
+func bufPtr(data []byte) *C.char {
+	if len(data) == 0 {
+		return nil
+	}
+	return (*C.char)(unsafe.Pointer(&data[0]))
+}
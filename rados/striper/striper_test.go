@@ -0,0 +1,242 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package striper
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tsuite "github.com/stretchr/testify/suite"
+
+	"github.com/ceph/go-ceph/rados"
+)
+
+type StriperTestSuite struct {
+	tsuite.Suite
+	conn    *rados.Conn
+	ioctx   *rados.IOContext
+	pool    string
+	striper *Striper
+	count   int
+}
+
+func (suite *StriperTestSuite) SetupSuite() {
+	conn, err := rados.NewConn()
+	require.NoError(suite.T(), err)
+	err = conn.ReadDefaultConfigFile()
+	require.NoError(suite.T(), err)
+
+	timeout := time.After(time.Second * 5)
+	ch := make(chan error)
+	go func(conn *rados.Conn) {
+		ch <- conn.Connect()
+	}(conn)
+	select {
+	case err = <-ch:
+	case <-timeout:
+		err = fmt.Errorf("timed out waiting for connect")
+	}
+	require.NoError(suite.T(), err)
+	suite.conn = conn
+
+	suite.pool = uuid.Must(uuid.NewV4()).String()
+	err = conn.MakePool(suite.pool)
+	require.NoError(suite.T(), err)
+	suite.ioctx, err = conn.OpenIOContext(suite.pool)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *StriperTestSuite) SetupTest() {
+	var err error
+	suite.count = 0
+	suite.striper, err = New(suite.ioctx)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *StriperTestSuite) TearDownTest() {
+	suite.striper.Destroy()
+}
+
+func (suite *StriperTestSuite) TearDownSuite() {
+	suite.ioctx.Destroy()
+	assert.NoError(suite.T(), suite.conn.DeletePool(suite.pool))
+	suite.conn.Shutdown()
+}
+
+func (suite *StriperTestSuite) GenObjectName() string {
+	name := fmt.Sprintf("%s_%d", suite.T().Name(), suite.count)
+	suite.count++
+	return name
+}
+
+func (suite *StriperTestSuite) RandomBytes(size int) []byte {
+	bytes := make([]byte, size)
+	n, err := rand.Read(bytes)
+	require.Equal(suite.T(), n, size)
+	require.NoError(suite.T(), err)
+	return bytes
+}
+
+func (suite *StriperTestSuite) TestNew() {
+	suite.T().Run("invalidIOContext", func(t *testing.T) {
+		_, err := New(&rados.IOContext{})
+		assert.Equal(t, rados.ErrInvalidIOContext, err)
+	})
+
+	suite.T().Run("invalidStriper", func(t *testing.T) {
+		s := &Striper{}
+		err := s.SetObjectLayoutStripeUnit(4096)
+		assert.Equal(t, ErrInvalidStriper, err)
+		err = s.WriteFull("foo", []byte("bar"))
+		assert.Equal(t, ErrInvalidStriper, err)
+		_, err = s.Read("foo", make([]byte, 3), 0)
+		assert.Equal(t, ErrInvalidStriper, err)
+		_, err = s.Stat("foo")
+		assert.Equal(t, ErrInvalidStriper, err)
+		_, err = s.ListXattrs("foo")
+		assert.Equal(t, ErrInvalidStriper, err)
+		_, err = s.AioRemove("foo")
+		assert.Equal(t, ErrInvalidStriper, err)
+		// destroying an invalid striper is harmless
+		s.Destroy()
+	})
+}
+
+func (suite *StriperTestSuite) TestReadWrite() {
+	s := suite.striper
+	require.NoError(suite.T(), s.SetObjectLayoutStripeUnit(65536))
+	require.NoError(suite.T(), s.SetObjectLayoutStripeCount(4))
+	require.NoError(suite.T(), s.SetObjectLayoutObjectSize(1<<20))
+
+	suite.T().Run("writeFullRead", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		// span multiple rados objects
+		data := suite.RandomBytes(5 << 20)
+		err := s.WriteFull(oid, data)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, s.Remove(oid)) }()
+
+		out := make([]byte, len(data))
+		n, err := s.Read(oid, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, len(data), n)
+		assert.Equal(t, data, out)
+
+		stat, err := s.Stat(oid)
+		assert.NoError(t, err)
+		assert.EqualValues(t, len(data), stat.Size)
+	})
+
+	suite.T().Run("writeAppendTruncate", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		err := s.Write(oid, []byte("hello"), 0)
+		require.NoError(t, err)
+		defer func() { assert.NoError(t, s.Remove(oid)) }()
+
+		err = s.Append(oid, []byte(" world"))
+		assert.NoError(t, err)
+		err = s.Write(oid, []byte("J"), 6)
+		assert.NoError(t, err)
+
+		out := make([]byte, 32)
+		n, err := s.Read(oid, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello Jorld", string(out[:n]))
+
+		err = s.Truncate(oid, 5)
+		assert.NoError(t, err)
+		n, err = s.Read(oid, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(out[:n]))
+	})
+
+	suite.T().Run("missing", func(t *testing.T) {
+		oid := suite.GenObjectName()
+		_, err := s.Stat(oid)
+		assert.Equal(t, ErrNotFound, err)
+		err = s.Remove(oid)
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func (suite *StriperTestSuite) TestXattrs() {
+	s := suite.striper
+	oid := suite.GenObjectName()
+	err := s.WriteFull(oid, []byte("xattrs"))
+	require.NoError(suite.T(), err)
+	defer func() { assert.NoError(suite.T(), s.Remove(oid)) }()
+
+	err = s.SetXattr(oid, "color", []byte("blue"))
+	assert.NoError(suite.T(), err)
+	err = s.SetXattr(oid, "shape", []byte("square"))
+	assert.NoError(suite.T(), err)
+
+	buf := make([]byte, 16)
+	n, err := s.GetXattr(oid, "color", buf)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "blue", string(buf[:n]))
+
+	xattrs, err := s.ListXattrs(oid)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []byte("blue"), xattrs["color"])
+	assert.Equal(suite.T(), []byte("square"), xattrs["shape"])
+
+	err = s.RmXattr(oid, "color")
+	assert.NoError(suite.T(), err)
+	xattrs, err = s.ListXattrs(oid)
+	assert.NoError(suite.T(), err)
+	assert.NotContains(suite.T(), xattrs, "color")
+}
+
+func (suite *StriperTestSuite) TestAio() {
+	s := suite.striper
+	oid := suite.GenObjectName()
+	data := suite.RandomBytes(3 << 20)
+
+	comp, err := s.AioWriteFull(oid, data)
+	require.NoError(suite.T(), err)
+	assert.NoError(suite.T(), comp.Wait())
+
+	comp, err = s.AioAppend(oid, []byte("tail"))
+	require.NoError(suite.T(), err)
+	assert.NoError(suite.T(), comp.Wait())
+
+	comp, err = s.AioWrite(oid, []byte("head"), 0)
+	require.NoError(suite.T(), err)
+	assert.NoError(suite.T(), s.AioFlush())
+	assert.NoError(suite.T(), comp.Wait())
+
+	var stat rados.ObjectStat
+	comp, err = s.AioStat(oid, &stat)
+	require.NoError(suite.T(), err)
+	assert.NoError(suite.T(), comp.Wait())
+	assert.EqualValues(suite.T(), len(data)+4, stat.Size)
+
+	out := make([]byte, len(data)+4)
+	comp, err = s.AioRead(oid, out, 0)
+	require.NoError(suite.T(), err)
+	<-comp.Done()
+	n, err := comp.Result()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), len(out), n)
+	assert.Equal(suite.T(), []byte("head"), out[:4])
+	assert.Equal(suite.T(), data[4:], out[4:len(data)])
+	assert.Equal(suite.T(), []byte("tail"), out[len(data):])
+
+	comp, err = s.AioRemove(oid)
+	require.NoError(suite.T(), err)
+	assert.NoError(suite.T(), comp.Wait())
+
+	_, err = s.Stat(oid)
+	assert.Equal(suite.T(), ErrNotFound, err)
+}
+
+func TestStriper(t *testing.T) {
+	tsuite.Run(t, new(StriperTestSuite))
+}
@@ -0,0 +1,128 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package striper
+
+// #cgo LDFLAGS: -lrados -lradosstriper
+// #include <stdlib.h>
+// #include <radosstriper/libradosstriper.h>
+import "C"
+
+import (
+	"unsafe"
+)
+
+// GetXattr reads the xattr with key name of the striped object with key
+// soid into data. It returns the length of the value read.
+//
+// Implements:
+//
+//	int rados_striper_getxattr(rados_striper_t striper, const char *oid,
+//	                           const char *name, char *buf, size_t len);
+func (s *Striper) GetXattr(soid, name string, data []byte) (int, error) {
+	if err := s.validate(); err != nil {
+		return 0, err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.rados_striper_getxattr(
+		s.striper,
+		cSoid,
+		cName,
+		bufPtr(data),
+		C.size_t(len(data)))
+	if ret >= 0 {
+		return int(ret), nil
+	}
+	return 0, getError(ret)
+}
+
+// SetXattr sets the xattr with key name of the striped object with key soid
+// to data.
+//
+// Implements:
+//
+//	int rados_striper_setxattr(rados_striper_t striper, const char *oid,
+//	                           const char *name, const char *buf, size_t len);
+func (s *Striper) SetXattr(soid, name string, data []byte) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	ret := C.rados_striper_setxattr(
+		s.striper,
+		cSoid,
+		cName,
+		bufPtr(data),
+		C.size_t(len(data)))
+	return getError(ret)
+}
+
+// RmXattr removes the xattr with key name from the striped object with key
+// soid.
+//
+// Implements:
+//
+//	int rados_striper_rmxattr(rados_striper_t striper, const char *oid,
+//	                          const char *name);
+func (s *Striper) RmXattr(soid, name string) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	return getError(C.rados_striper_rmxattr(s.striper, cSoid, cName))
+}
+
+// ListXattrs lists all the xattrs of the striped object with key soid. The
+// xattrs are returned as a mapping of string keys and byte-slice values.
+//
+// Implements:
+//
+//	int rados_striper_getxattrs(rados_striper_t striper, const char *oid,
+//	                            rados_xattrs_iter_t *iter);
+//	int rados_striper_getxattrs_next(rados_xattrs_iter_t iter,
+//	                                 const char **name, const char **val,
+//	                                 size_t *len);
+//	void rados_striper_getxattrs_end(rados_xattrs_iter_t iter);
+func (s *Striper) ListXattrs(soid string) (map[string][]byte, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	cSoid := C.CString(soid)
+	defer C.free(unsafe.Pointer(cSoid))
+
+	var it C.rados_xattrs_iter_t
+	ret := C.rados_striper_getxattrs(s.striper, cSoid, &it)
+	if ret < 0 {
+		return nil, getError(ret)
+	}
+	defer C.rados_striper_getxattrs_end(it)
+
+	m := make(map[string][]byte)
+	for {
+		var (
+			cName, cVal *C.char
+			cLen        C.size_t
+		)
+		ret := C.rados_striper_getxattrs_next(it, &cName, &cVal, &cLen)
+		if ret < 0 {
+			return nil, getError(ret)
+		}
+		// a null name marks the end of the iteration
+		if cName == nil {
+			return m, nil
+		}
+		m[C.GoString(cName)] = C.GoBytes(unsafe.Pointer(cVal), C.int(cLen))
+	}
+}
@@ -8,6 +8,7 @@ package rados
 import "C"
 
 import (
+	"time"
 	"unsafe"
 
 	"github.com/ceph/go-ceph/internal/cutil"
@@ -22,6 +23,9 @@ type Timespec ts.Timespec
 type WriteOp struct {
 	operation
 	op C.rados_write_op_t
+	// err records an invalid argument of an action, which is returned when
+	// the operation is performed.
+	err error
 }
 
 // CreateWriteOp returns a newly constructed write operation.
@@ -33,17 +37,27 @@ func CreateWriteOp() *WriteOp {
 
 // Release the resources associated with this write operation.
 func (w *WriteOp) Release() {
-	C.rados_release_write_op(w.op)
-	w.op = nil
-	w.free()
+	w.release(func() {
+		C.rados_release_write_op(w.op)
+		w.op = nil
+		w.free()
+	})
 }
 
 func (w WriteOp) operate2(
-	ioctx *IOContext, oid string, mtime *Timespec, flags OperationFlags) error {
+	ioctx *IOContext, oid string, mtime *Timespec, flags OperationFlags) (err error) {
 
+	if ioctx.observer != nil {
+		defer func(start time.Time) {
+			ioctx.observe("rados.WriteOp.Operate", oid, 0, start, err)
+		}(time.Now())
+	}
 	if err := ioctx.validate(); err != nil {
 		return err
 	}
+	if w.err != nil {
+		return w.err
+	}
 
 	cOid := C.CString(oid)
 	defer C.free(unsafe.Pointer(cOid))
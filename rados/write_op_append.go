@@ -0,0 +1,26 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+// Append a given byte slice to the end of the object.
+//
+// Implements:
+//
+//	void rados_write_op_append(rados_write_op_t write_op,
+//	                           const char *buffer,
+//	                           size_t len);
+func (w *WriteOp) Append(b []byte) {
+	oe := newWriteStep(b, 0, 0)
+	w.steps = append(w.steps, oe)
+	C.rados_write_op_append(
+		w.op,
+		oe.cBuffer,
+		oe.cDataLen)
+}
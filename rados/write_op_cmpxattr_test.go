@@ -0,0 +1,51 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpCmpXattr() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateExclusive)
+	op1.SetXattr("state", []byte("ready"))
+	err := op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// The comparison fails, nothing is changed.
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.CmpXattr("state", CompareEqual, []byte("busy"))
+	op2.SetXattr("state", []byte("done"))
+	op2.WriteFull([]byte("result"))
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+
+	stat, err := suite.ioctx.Stat(oid)
+	ta.NoError(err)
+	ta.Equal(uint64(0), stat.Size)
+
+	// The comparison succeeds, all changes are applied.
+	op3 := CreateWriteOp()
+	defer op3.Release()
+	op3.CmpXattr("state", CompareEqual, []byte("ready"))
+	op3.SetXattr("state", []byte("done"))
+	op3.WriteFull([]byte("result"))
+	err = op3.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	buf := make([]byte, 16)
+	n, err := suite.ioctx.GetXattr(oid, "state", buf)
+	ta.NoError(err)
+	ta.Equal("done", string(buf[:n]))
+	n, err = suite.ioctx.Read(oid, buf, 0)
+	ta.NoError(err)
+	ta.Equal("result", string(buf[:n]))
+}
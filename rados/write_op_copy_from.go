@@ -0,0 +1,51 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// CopyFrom replaces the object with a copy of the object with key srcOid in
+// the pool and namespace of the I/O context src. The data, xattrs and omap
+// of the source object are copied by the OSDs, without passing through the
+// client. If srcVersion is not zero the copy fails unless the source object
+// has that version. srcFlags are applied when reading the source object.
+// The src I/O context must stay open until the operation is complete. If
+// src is not a valid I/O context the error is returned when the operation
+// is performed.
+//
+// Implements:
+//
+//	void rados_write_op_copy_from(rados_write_op_t write_op,
+//	                              const char *src,
+//	                              rados_ioctx_t src_io,
+//	                              uint64_t src_version,
+//	                              uint32_t src_fadvise_flags);
+func (w *WriteOp) CopyFrom(src *IOContext, srcOid string, srcVersion uint64, srcFlags OpFlags) {
+	if src == nil {
+		w.err = ErrInvalidIOContext
+		return
+	}
+	if err := src.validate(); err != nil {
+		w.err = err
+		return
+	}
+
+	cSrcOid := C.CString(srcOid)
+	defer C.free(unsafe.Pointer(cSrcOid))
+
+	C.rados_write_op_copy_from(
+		w.op,
+		cSrcOid,
+		src.ioctx,
+		C.uint64_t(srcVersion),
+		C.uint32_t(srcFlags))
+}
@@ -0,0 +1,68 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *RadosTestSuite) TestWriteOpCopyFrom() {
+	suite.SetupConnection()
+
+	src := suite.GenObjectName()
+	data := suite.RandomBytes(4096)
+	err := suite.ioctx.WriteFull(src, data)
+	require.NoError(suite.T(), err)
+	version, err := suite.ioctx.GetLastVersion()
+	require.NoError(suite.T(), err)
+
+	suite.T().Run("copy", func(t *testing.T) {
+		dst := suite.GenObjectName()
+		op := CreateWriteOp()
+		defer op.Release()
+		op.CopyFrom(suite.ioctx, src, version, OpFlagFAdviseSequential)
+		err := op.Operate(suite.ioctx, dst, OperationNoFlag)
+		assert.NoError(t, err)
+
+		out := make([]byte, len(data))
+		n, err := suite.ioctx.Read(dst, out, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, len(data), n)
+		assert.Equal(t, data, out)
+	})
+
+	suite.T().Run("versionMismatch", func(t *testing.T) {
+		dst := suite.GenObjectName()
+		op := CreateWriteOp()
+		defer op.Release()
+		op.CopyFrom(suite.ioctx, src, version+1, OpFlagNone)
+		err := op.Operate(suite.ioctx, dst, OperationNoFlag)
+		assert.Error(t, err)
+		_, err = suite.ioctx.Stat(dst)
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	suite.T().Run("missingSource", func(t *testing.T) {
+		op := CreateWriteOp()
+		defer op.Release()
+		op.CopyFrom(suite.ioctx, suite.GenObjectName(), 0, OpFlagNone)
+		err := op.Operate(suite.ioctx, suite.GenObjectName(), OperationNoFlag)
+		assert.Error(t, err)
+	})
+
+	suite.T().Run("invalidSource", func(t *testing.T) {
+		for _, src := range []*IOContext{nil, {}} {
+			op := CreateWriteOp()
+			op.CopyFrom(src, "src", 0, OpFlagNone)
+			err := op.Operate(suite.ioctx, suite.GenObjectName(), OperationNoFlag)
+			assert.Equal(t, ErrInvalidIOContext, err)
+			_, err = op.AioOperate(suite.ioctx, suite.GenObjectName(), OperationNoFlag)
+			assert.Equal(t, ErrInvalidIOContext, err)
+			op.Release()
+		}
+	})
+}
@@ -0,0 +1,76 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// WriteOpExecStep holds the result of the Exec write operation.
+// Result is valid only after Operate() was called.
+type WriteOpExecStep struct {
+	// C returned data:
+	prval *C.int
+
+	// Result of the Exec write operation.
+	Result int
+}
+
+func (s *WriteOpExecStep) update() error {
+	s.Result = int(*s.prval)
+	return nil
+}
+
+func (s *WriteOpExecStep) free() {
+	C.free(unsafe.Pointer(s.prval))
+	s.prval = nil
+}
+
+func newWriteOpExecStep() *WriteOpExecStep {
+	return &WriteOpExecStep{
+		prval: (*C.int)(C.malloc(C.sizeof_int)),
+	}
+}
+
+// Exec executes the method of an object class (cls) as part of the write
+// operation. Object class methods can not return data as part of a write
+// operation.
+//
+// Implements:
+//
+//	void rados_write_op_exec(rados_write_op_t write_op,
+//	                         const char *cls,
+//	                         const char *method,
+//	                         const char *in_buf,
+//	                         size_t in_len,
+//	                         int *prval);
+func (w *WriteOp) Exec(cls, method string, in []byte) *WriteOpExecStep {
+	execStep := newWriteOpExecStep()
+	w.steps = append(w.steps, execStep)
+
+	cCls := C.CString(cls)
+	defer C.free(unsafe.Pointer(cCls))
+	cMethod := C.CString(method)
+	defer C.free(unsafe.Pointer(cMethod))
+	var cIn *C.char
+	if len(in) > 0 {
+		cIn = (*C.char)(unsafe.Pointer(&in[0]))
+	}
+
+	C.rados_write_op_exec(
+		w.op,
+		cCls,
+		cMethod,
+		cIn,
+		C.size_t(len(in)),
+		execStep.prval)
+
+	return execStep
+}
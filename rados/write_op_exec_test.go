@@ -0,0 +1,41 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpExec() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+
+	// record_hello writes a greeting to the object and fails if the object
+	// already exists
+	op := CreateWriteOp()
+	defer op.Release()
+	execStep := op.Exec("hello", "record_hello", []byte("write op"))
+	op.SetXattr("key", []byte("value"))
+	err := op.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal(0, execStep.Result)
+
+	buf := make([]byte, 64)
+	n, err := suite.ioctx.Read(oid, buf, 0)
+	ta.NoError(err)
+	ta.Equal("Hello, write op!", string(buf[:n]))
+	xbuf := make([]byte, 16)
+	n, err = suite.ioctx.GetXattr(oid, "key", xbuf)
+	ta.NoError(err)
+	ta.Equal("value", string(xbuf[:n]))
+
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	execStep = op2.Exec("hello", "record_hello", []byte("again"))
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+	ta.NotEqual(0, execStep.Result)
+}
@@ -0,0 +1,77 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// WriteOpOmapCmpStep holds the result of the OmapCmp write operation.
+// Result is valid only after Operate() was called.
+type WriteOpOmapCmpStep struct {
+	// C returned data:
+	prval *C.int
+
+	// Result of the OmapCmp write operation.
+	Result int
+}
+
+func (s *WriteOpOmapCmpStep) update() error {
+	s.Result = int(*s.prval)
+	return nil
+}
+
+func (s *WriteOpOmapCmpStep) free() {
+	C.free(unsafe.Pointer(s.prval))
+	s.prval = nil
+}
+
+func newWriteOpOmapCmpStep() *WriteOpOmapCmpStep {
+	s := &WriteOpOmapCmpStep{
+		prval: (*C.int)(C.malloc(C.sizeof_int)),
+	}
+	*s.prval = 0
+	return s
+}
+
+// OmapCmp ensures that the value of the omap key compares to value as
+// required by op before writing. The value stored in the omap is the first
+// operand of the comparison. If the comparison fails the operation fails
+// with an error wrapping -ECANCELED and none of the actions of the operation
+// are applied.
+//
+// Implements:
+//
+//	void rados_write_op_omap_cmp(rados_write_op_t write_op,
+//	                             const char *key,
+//	                             uint8_t comparison_operator,
+//	                             const char *val,
+//	                             size_t val_len,
+//	                             int *prval);
+func (w *WriteOp) OmapCmp(key string, op CompareOp, value []byte) *WriteOpOmapCmpStep {
+	cmpStep := newWriteOpOmapCmpStep()
+	w.steps = append(w.steps, cmpStep)
+
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	var cValue *C.char
+	if len(value) > 0 {
+		cValue = (*C.char)(unsafe.Pointer(&value[0]))
+	}
+
+	C.rados_write_op_omap_cmp(
+		w.op,
+		cKey,
+		C.uint8_t(op),
+		cValue,
+		C.size_t(len(value)),
+		cmpStep.prval)
+	return cmpStep
+}
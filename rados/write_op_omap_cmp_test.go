@@ -0,0 +1,46 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpOmapCmp() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateExclusive)
+	op1.SetOmap(map[string][]byte{"owner": []byte("alice")})
+	err := op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// The comparison fails, nothing is changed.
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.OmapCmp("owner", CompareEqual, []byte("bob"))
+	op2.SetOmap(map[string][]byte{"owner": []byte("carol")})
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.Error(err)
+
+	vals, err := suite.ioctx.GetAllOmapValues(oid, "", "", 10)
+	ta.NoError(err)
+	ta.Equal(map[string][]byte{"owner": []byte("alice")}, vals)
+
+	// The comparison succeeds, the omap is updated.
+	op3 := CreateWriteOp()
+	defer op3.Release()
+	cmpStep := op3.OmapCmp("owner", CompareEqual, []byte("alice"))
+	op3.SetOmap(map[string][]byte{"owner": []byte("carol")})
+	err = op3.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+	ta.Equal(0, cmpStep.Result)
+
+	vals, err = suite.ioctx.GetAllOmapValues(oid, "", "", 10)
+	ta.NoError(err)
+	ta.Equal(map[string][]byte{"owner": []byte("carol")}, vals)
+}
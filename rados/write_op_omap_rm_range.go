@@ -0,0 +1,38 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+import (
+	"unsafe"
+)
+
+// OmapRmKeyRange removes all the omap keys of the object that are greater
+// than or equal to begin and less than end.
+//
+// Implements:
+//
+//	void rados_write_op_omap_rm_range2(rados_write_op_t write_op,
+//	                                   const char *key_begin,
+//	                                   size_t key_begin_len,
+//	                                   const char *key_end,
+//	                                   size_t key_end_len);
+func (w *WriteOp) OmapRmKeyRange(begin, end string) {
+	cBegin := C.CString(begin)
+	defer C.free(unsafe.Pointer(cBegin))
+	cEnd := C.CString(end)
+	defer C.free(unsafe.Pointer(cEnd))
+
+	C.rados_write_op_omap_rm_range2(
+		w.op,
+		cBegin,
+		C.size_t(len(begin)),
+		cEnd,
+		C.size_t(len(end)))
+}
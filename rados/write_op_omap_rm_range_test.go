@@ -0,0 +1,39 @@
+//go:build !nautilus && ceph_preview
+// +build !nautilus,ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpOmapRmKeyRange() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateExclusive)
+	op1.SetOmap(map[string][]byte{
+		"a":  []byte("1"),
+		"b1": []byte("2"),
+		"b2": []byte("3"),
+		"c":  []byte("4"),
+	})
+	err := op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.OmapRmKeyRange("b", "c")
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	vals, err := suite.ioctx.GetAllOmapValues(oid, "", "", 10)
+	ta.NoError(err)
+	ta.Equal(map[string][]byte{
+		"a": []byte("1"),
+		"c": []byte("4"),
+	}, vals)
+}
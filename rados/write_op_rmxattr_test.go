@@ -0,0 +1,32 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpRmXattr() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Create(CreateExclusive)
+	op1.SetXattr("color", []byte("blue"))
+	op1.SetXattr("shape", []byte("square"))
+	err := op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.RmXattr("color")
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	xattrs, err := suite.ioctx.ListXattrs(oid)
+	ta.NoError(err)
+	ta.Equal(map[string][]byte{"shape": []byte("square")}, xattrs)
+}
@@ -0,0 +1,22 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+// SetFlags sets flags for the last action added to the write operation,
+// for example OpFlagFAdviseDontNeed for a write of data that is not read
+// back soon.
+//
+// Implements:
+//
+//	void rados_write_op_set_flags(rados_write_op_t write_op,
+//	                              int flags);
+func (w *WriteOp) SetFlags(flags OpFlags) {
+	C.rados_write_op_set_flags(w.op, C.int(flags))
+}
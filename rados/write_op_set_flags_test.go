@@ -0,0 +1,35 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpSetFlags() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.WriteFull([]byte("not cached"))
+	op1.SetFlags(OpFlagFAdviseDontNeed | OpFlagFAdviseNoCache)
+	err := op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	// the failure of the flagged action is ignored
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.Create(CreateExclusive)
+	op2.SetFlags(OpFlagExcl | OpFlagFailOk)
+	op2.Append([]byte("!"))
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	buf := make([]byte, 64)
+	n, err := suite.ioctx.Read(oid, buf, 0)
+	ta.NoError(err)
+	ta.Equal("not cached!", string(buf[:n]))
+}
@@ -0,0 +1,33 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+// #cgo LDFLAGS: -lrados
+// #include <rados/librados.h>
+// #include <stdlib.h>
+//
+import "C"
+
+// Truncate sets the size of the object to offset. If the object is enlarged
+// the new area is logically filled with zeroes.
+//
+// Implements:
+//
+//	void rados_write_op_truncate(rados_write_op_t write_op,
+//	                             uint64_t offset);
+func (w *WriteOp) Truncate(offset uint64) {
+	C.rados_write_op_truncate(w.op, C.uint64_t(offset))
+}
+
+// Zero sets length bytes of the object starting at byte offset offset to
+// zero.
+//
+// Implements:
+//
+//	void rados_write_op_zero(rados_write_op_t write_op,
+//	                         uint64_t offset,
+//	                         uint64_t len);
+func (w *WriteOp) Zero(offset, length uint64) {
+	C.rados_write_op_zero(w.op, C.uint64_t(offset), C.uint64_t(length))
+}
@@ -0,0 +1,40 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rados
+
+import (
+	"github.com/stretchr/testify/assert"
+)
+
+func (suite *RadosTestSuite) TestWriteOpTruncateZero() {
+	suite.SetupConnection()
+	ta := assert.New(suite.T())
+
+	oid := suite.GenObjectName()
+	err := suite.ioctx.WriteFull(oid, []byte("0123456789"))
+	ta.NoError(err)
+
+	op1 := CreateWriteOp()
+	defer op1.Release()
+	op1.Zero(2, 3)
+	op1.Truncate(8)
+	err = op1.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	buf := make([]byte, 64)
+	n, err := suite.ioctx.Read(oid, buf, 0)
+	ta.NoError(err)
+	ta.Equal([]byte("01\x00\x00\x00567"), buf[:n])
+
+	// truncating beyond the end fills the object with zeroes
+	op2 := CreateWriteOp()
+	defer op2.Release()
+	op2.Truncate(10)
+	err = op2.Operate(suite.ioctx, oid, OperationNoFlag)
+	ta.NoError(err)
+
+	n, err = suite.ioctx.Read(oid, buf, 0)
+	ta.NoError(err)
+	ta.Equal([]byte("01\x00\x00\x00567\x00\x00"), buf[:n])
+}
@@ -0,0 +1,55 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rbd
+
+/*
+#cgo LDFLAGS: -lrbd
+#include <stdlib.h>
+#include <rbd/librbd.h>
+
+// inline wrapper to cast uintptr_t to void*
+static inline int wrap_rbd_aio_create_completion(void *cb, uintptr_t arg,
+	rbd_completion_t *c) {
+		return rbd_aio_create_completion((void*)arg,
+			(rbd_callback_t)cb, c);
+	};
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/aio"
+)
+
+// completionOps are the librbd calls managing the C completions.
+var completionOps = &aio.Ops{
+	Create: func(cb unsafe.Pointer, arg uintptr) (unsafe.Pointer, int) {
+		var c C.rbd_completion_t
+		ret := C.wrap_rbd_aio_create_completion(cb, C.uintptr_t(arg), &c)
+		return unsafe.Pointer(c), int(ret)
+	},
+	Release: func(c unsafe.Pointer) {
+		C.rbd_aio_release(C.rbd_completion_t(c))
+	},
+	ReturnValue: func(c unsafe.Pointer) int {
+		return int(C.rbd_aio_get_return_value(C.rbd_completion_t(c)))
+	},
+	Error: func(ret int) error {
+		if ret < 0 {
+			return rbdError(ret)
+		}
+		return nil
+	},
+}
+
+// newCompletion returns a completion for an asynchronous librbd call. The
+// finisher is not called if the caller stopped waiting for the operation.
+func newCompletion(finish aio.Finisher, free func()) (*aio.Completion, C.rbd_completion_t, error) {
+	comp, err := aio.New(completionOps, finish, free)
+	if err != nil {
+		return nil, nil, err
+	}
+	return comp, C.rbd_completion_t(comp.Handle()), nil
+}
@@ -0,0 +1,170 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rbd
+
+/*
+#cgo LDFLAGS: -lrbd
+#include <stdlib.h>
+#include <rbd/librbd.h>
+*/
+import "C"
+
+import (
+	"context"
+	"io"
+	"time"
+	"unsafe"
+
+	"github.com/ceph/go-ceph/internal/cutil"
+)
+
+// The context aware variants of the blocking Image calls are built on the
+// asynchronous librbd calls. If the context is done before the operation is
+// complete ctx.Err() is returned right away. The operation can not be
+// aborted, so it continues in the cluster, but its outputs are left
+// untouched.
+
+// ReadAtContext copies data from the image into the supplied buffer, like
+// ReadAt. It returns ctx.Err() if the context is done before the read is
+// complete, in which case data is not modified.
+//
+// Implements:
+//
+//	int rbd_aio_read(rbd_image_t image, uint64_t off, size_t len, char *buf,
+//	                 rbd_completion_t c);
+func (image *Image) ReadAtContext(ctx context.Context, data []byte, off int64) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.ReadAtContext", int64(n), start, err)
+		}(time.Now())
+	}
+
+	if err := image.validate(imageIsOpen); err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// librbd writes to the buffer after this call returns, so we have to
+	// use C memory and copy the result to the Go buffer on completion
+	cBuf := C.malloc(C.size_t(len(data)))
+	comp, c, err := newCompletion(
+		func(ret int) error {
+			if ret < 0 {
+				return rbdError(ret)
+			}
+			if ret > 0 {
+				cutil.Memcpy(
+					cutil.CPtr(unsafe.Pointer(&data[0])),
+					cutil.CPtr(cBuf),
+					cutil.SizeT(ret))
+			}
+			return nil
+		},
+		func() { C.free(cBuf) })
+	if err != nil {
+		return 0, err
+	}
+
+	ret := C.rbd_aio_read(
+		image.image,
+		C.uint64_t(off),
+		C.size_t(len(data)),
+		(*C.char)(cBuf),
+		c)
+	if err := comp.Submitted(int(ret)); err != nil {
+		return 0, err
+	}
+	if err := comp.WaitContext(ctx); err != nil {
+		return 0, err
+	}
+	n, _ = comp.Result()
+	if n < len(data) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// WriteAtContext copies data from the supplied buffer to the image, like
+// WriteAt. The data is copied before the write is started. It returns
+// ctx.Err() if the context is done before the write is complete, in which
+// case the write may or may not be applied.
+//
+// Implements:
+//
+//	int rbd_aio_write(rbd_image_t image, uint64_t off, size_t len,
+//	                  const char *buf, rbd_completion_t c);
+func (image *Image) WriteAtContext(ctx context.Context, data []byte, off int64) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.WriteAtContext", int64(n), start, err)
+		}(time.Now())
+	}
+
+	if err := image.validate(imageIsOpen); err != nil {
+		return 0, err
+	}
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// librbd may use the buffer until the write is complete, which can be
+	// after we stopped waiting for it
+	cBuf := C.CBytes(data)
+	comp, c, err := newCompletion(nil, func() { C.free(cBuf) })
+	if err != nil {
+		return 0, err
+	}
+
+	ret := C.rbd_aio_write(
+		image.image,
+		C.uint64_t(off),
+		C.size_t(len(data)),
+		(*C.char)(cBuf),
+		c)
+	if err := comp.Submitted(int(ret)); err != nil {
+		return 0, err
+	}
+	if err := comp.WaitContext(ctx); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// FlushContext flushes all cached writes to the cluster, like Flush. It
+// returns ctx.Err() if the context is done before the flush is complete.
+//
+// Implements:
+//
+//	int rbd_aio_flush(rbd_image_t image, rbd_completion_t c);
+func (image *Image) FlushContext(ctx context.Context) (err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.FlushContext", 0, start, err)
+		}(time.Now())
+	}
+
+	if err := image.validate(imageIsOpen); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	comp, c, err := newCompletion(nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := comp.Submitted(int(C.rbd_aio_flush(image.image, c))); err != nil {
+		return err
+	}
+	return comp.WaitContext(ctx)
+}
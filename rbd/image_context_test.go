@@ -0,0 +1,78 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rbd
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageContext(t *testing.T) {
+	conn := radosConnect(t)
+	require.NotNil(t, conn)
+	defer conn.Shutdown()
+
+	poolname := GetUUID()
+	err := conn.MakePool(poolname)
+	require.NoError(t, err)
+	defer conn.DeletePool(poolname)
+
+	ioctx, err := conn.OpenIOContext(poolname)
+	require.NoError(t, err)
+	defer ioctx.Destroy()
+
+	name := GetUUID()
+	options := NewRbdImageOptions()
+	defer options.Destroy()
+	assert.NoError(t, options.SetUint64(ImageOptionOrder, uint64(testImageOrder)))
+	err = CreateImage(ioctx, name, 1<<22, options)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, RemoveImage(ioctx, name)) }()
+
+	img, err := OpenImage(ioctx, name, NoSnapshot)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, img.Close()) }()
+
+	ctx := context.Background()
+	data := []byte("Hi rbd! Nice to talk through go-ceph :)")
+	n, err := img.WriteAtContext(ctx, data, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.NoError(t, img.FlushContext(ctx))
+
+	out := make([]byte, len(data))
+	n, err = img.ReadAtContext(ctx, out, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	assert.Equal(t, data, out)
+
+	// reading past the end of the image is short
+	n, err = img.ReadAtContext(ctx, out, 1<<22-4)
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 4, n)
+
+	t.Run("cancelled", func(t *testing.T) {
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := img.WriteAtContext(cctx, data, 0)
+		assert.Equal(t, context.Canceled, err)
+		_, err = img.ReadAtContext(cctx, out, 0)
+		assert.Equal(t, context.Canceled, err)
+		err = img.FlushContext(cctx)
+		assert.Equal(t, context.Canceled, err)
+	})
+
+	t.Run("closedImage", func(t *testing.T) {
+		closed := GetImage(ioctx, name)
+		_, err := closed.ReadAtContext(ctx, out, 0)
+		assert.Equal(t, ErrImageNotOpen, err)
+		_, err = closed.WriteAtContext(ctx, data, 0)
+		assert.Equal(t, ErrImageNotOpen, err)
+		assert.Equal(t, ErrImageNotOpen, closed.FlushContext(ctx))
+	})
+}
@@ -0,0 +1,37 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rbd
+
+import (
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// SetObserver sets the observer that is informed about the I/O operations
+// performed on the image, which are the Read, ReadAt, Write, WriteAt,
+// WriteSame, Discard and Flush functions of the Image, and their context
+// aware variants. Passing nil removes the observer. The observer must not
+// be changed while the image is in use by other goroutines.
+func (image *Image) SetObserver(o observer.Observer) error {
+	if err := image.validate(imageNeedsIOContext); err != nil {
+		return err
+	}
+	if o == nil {
+		image.observer = nil
+		return nil
+	}
+	pool, err := image.ioctx.GetPoolName()
+	if err != nil {
+		return err
+	}
+	namespace, err := image.ioctx.GetNamespace()
+	if err != nil {
+		return err
+	}
+	image.observer = &imageObserver{
+		observer:  o,
+		pool:      pool,
+		namespace: namespace,
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+//go:build ceph_preview
+// +build ceph_preview
+
+package rbd
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ceph/go-ceph/internal/observertest"
+)
+
+func TestImageSetObserver(t *testing.T) {
+	conn := radosConnect(t)
+	require.NotNil(t, conn)
+	defer conn.Shutdown()
+
+	poolname := GetUUID()
+	err := conn.MakePool(poolname)
+	require.NoError(t, err)
+	defer conn.DeletePool(poolname)
+
+	ioctx, err := conn.OpenIOContext(poolname)
+	require.NoError(t, err)
+	defer ioctx.Destroy()
+
+	name := GetUUID()
+	options := NewRbdImageOptions()
+	defer options.Destroy()
+	assert.NoError(t, options.SetUint64(ImageOptionOrder, uint64(testImageOrder)))
+	err = CreateImage(ioctx, name, 1<<22, options)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, RemoveImage(ioctx, name)) }()
+
+	img, err := OpenImage(ioctx, name, NoSnapshot)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, img.Close()) }()
+
+	assert.Equal(t, ErrNoIOContext, (&Image{}).SetObserver(nil))
+
+	rec := &observertest.Recorder{}
+	require.NoError(t, img.SetObserver(rec))
+	data := []byte("observed data")
+	n, err := img.WriteAt(data, 0)
+	require.NoError(t, err)
+	assert.Equal(t, len(data), n)
+	buf := make([]byte, len(data))
+	_, err = img.ReadAt(buf, 0)
+	require.NoError(t, err)
+	_, err = img.ReadAt(make([]byte, 16), 1<<22-8)
+	assert.Equal(t, io.EOF, err)
+	require.NoError(t, img.Flush())
+
+	ops := rec.Operations()
+	require.Len(t, ops, 4)
+	for _, op := range ops {
+		assert.Equal(t, poolname, op.Pool)
+		assert.Equal(t, name, op.Image)
+	}
+	assert.Equal(t, "rbd.Image.WriteAt", ops[0].Name)
+	assert.EqualValues(t, len(data), ops[0].Bytes)
+	assert.Equal(t, "rbd.Image.ReadAt", ops[1].Name)
+	assert.EqualValues(t, len(data), ops[1].Bytes)
+	assert.Equal(t, io.EOF, ops[2].Err)
+	assert.EqualValues(t, 8, ops[2].Bytes)
+	assert.Equal(t, "rbd.Image.Flush", ops[3].Name)
+
+	require.NoError(t, img.SetObserver(nil))
+	_, err = img.ReadAt(buf, 0)
+	assert.NoError(t, err)
+	assert.Len(t, rec.Operations(), 4)
+}
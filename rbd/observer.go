@@ -0,0 +1,34 @@
+package rbd
+
+import (
+	"time"
+
+	"github.com/ceph/go-ceph/common/observer"
+)
+
+// imageObserver holds the observer of an Image along with the details of the
+// image that are reported with every operation.
+type imageObserver struct {
+	observer  observer.Observer
+	pool      string
+	namespace string
+}
+
+// observe informs the observer of the image about a completed operation. It
+// must only be called if the image has an observer.
+func (image *Image) observe(name string, bytes int64, start time.Time, err error) {
+	if bytes < 0 {
+		// some of the image functions return the negative error code
+		bytes = 0
+	}
+	o := image.observer
+	o.observer.ObserveOperation(observer.Operation{
+		Name:      name,
+		Pool:      o.pool,
+		Namespace: o.namespace,
+		Image:     image.name,
+		Bytes:     bytes,
+		Duration:  time.Since(start),
+		Err:       err,
+	})
+}
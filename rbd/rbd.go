@@ -87,6 +87,10 @@ type Image struct {
 	offset int64
 	ioctx  *rados.IOContext
 	image  C.rbd_image_t
+
+	// observer, if set, is informed about the I/O operations performed on
+	// the image.
+	observer *imageObserver
 }
 
 // TrashInfo contains information about trashed RBDs.
@@ -738,7 +742,13 @@ func (image *Image) BreakLock(client string, cookie string) error {
 //
 //	ssize_t rbd_read(rbd_image_t image, uint64_t ofs, size_t len,
 //	                 char *buf);
-func (image *Image) Read(data []byte) (int, error) {
+func (image *Image) Read(data []byte) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.Read", int64(n), start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return 0, err
 	}
@@ -774,6 +784,12 @@ func (image *Image) Read(data []byte) (int, error) {
 //	ssize_t rbd_write(rbd_image_t image, uint64_t ofs, size_t len,
 //	                  const char *buf);
 func (image *Image) Write(data []byte) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.Write", int64(n), start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return 0, err
 	}
@@ -818,7 +834,13 @@ func (image *Image) Seek(offset int64, whence int) (int64, error) {
 // Implements:
 //
 //	int rbd_discard(rbd_image_t image, uint64_t ofs, uint64_t len);
-func (image *Image) Discard(ofs uint64, length uint64) (int, error) {
+func (image *Image) Discard(ofs uint64, length uint64) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.Discard", 0, start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return 0, err
 	}
@@ -832,7 +854,13 @@ func (image *Image) Discard(ofs uint64, length uint64) (int, error) {
 }
 
 // ReadAt copies data from the image into the supplied buffer.
-func (image *Image) ReadAt(data []byte, off int64) (int, error) {
+func (image *Image) ReadAt(data []byte, off int64) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.ReadAt", int64(n), start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return 0, err
 	}
@@ -860,6 +888,12 @@ func (image *Image) ReadAt(data []byte, off int64) (int, error) {
 
 // WriteAt copies data from the supplied buffer to the image.
 func (image *Image) WriteAt(data []byte, off int64) (n int, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.WriteAt", int64(n), start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return 0, err
 	}
@@ -885,8 +919,12 @@ func (image *Image) WriteAt(data []byte, off int64) (n int, err error) {
 //
 //	ssize_t rbd_writesame(rbd_image_t image, uint64_t ofs, size_t len,
 //	                      const char *buf, size_t data_len, int op_flags);
-func (image *Image) WriteSame(ofs, n uint64, data []byte, flags rados.OpFlags) (int64, error) {
-	var err error
+func (image *Image) WriteSame(ofs, n uint64, data []byte, flags rados.OpFlags) (written int64, err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.WriteSame", written, start, err)
+		}(time.Now())
+	}
 
 	if err = image.validate(imageIsOpen); err != nil {
 		return 0, err
@@ -914,7 +952,13 @@ func (image *Image) WriteSame(ofs, n uint64, data []byte, flags rados.OpFlags) (
 // Implements:
 //
 //	int rbd_flush(rbd_image_t image);
-func (image *Image) Flush() error {
+func (image *Image) Flush() (err error) {
+	if image.observer != nil {
+		defer func(start time.Time) {
+			image.observe("rbd.Image.Flush", 0, start, err)
+		}(time.Now())
+	}
+
 	if err := image.validate(imageIsOpen); err != nil {
 		return err
 	}